@@ -0,0 +1,347 @@
+package openapiv3
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BreakingRule classifies the changes that break the clients of an API.
+type BreakingRule struct {
+	// ID identifies the rule, for instance to disable it.
+	ID string
+	// Description explains what the rule detects.
+	Description string
+	// Match reports whether the change is breaking according to the rule.
+	Match func(c Change) bool
+}
+
+// BreakingRules is a set of rules to classify changes.
+type BreakingRules []BreakingRule
+
+// DefaultBreakingRules returns the built-in rules to detect breaking changes.
+func DefaultBreakingRules() BreakingRules {
+	return BreakingRules{
+		{ID: "operation-removed", Description: "an operation has been removed", Match: operationRemoved},
+		{ID: "response-removed", Description: "a response code has been removed", Match: responseRemoved},
+		{ID: "parameter-required", Description: "a parameter is now required", Match: parameterRequired},
+		{ID: "request-body-required", Description: "the request body is now required", Match: requestBodyRequired},
+		{ID: "request-property-required", Description: "a request property is now required", Match: requestPropertyRequired},
+		{ID: "enum-narrowed", Description: "a request enum accepts less values", Match: enumNarrowed},
+		{ID: "constraint-tightened", Description: "a request constraint accepts less values", Match: constraintTightened},
+		{ID: "type-changed", Description: "the type or the format of a value has changed", Match: typeChanged},
+		{ID: "response-property-removed", Description: "a response property has been removed", Match: responsePropertyRemoved},
+		{ID: "security-changed", Description: "the security requirements or schemes have changed", Match: securityChanged},
+	}
+}
+
+// Without returns the rules except the ones with the given IDs.
+func (r BreakingRules) Without(ids ...string) BreakingRules {
+	rules := make(BreakingRules, 0, len(r))
+	for _, rule := range r {
+		if !containsString(ids, rule.ID) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// BreakingChange is a change breaking the clients of an API.
+type BreakingChange struct {
+	Change
+	// Rules holds the IDs of the rules matching the change.
+	Rules []string `json:"rules"`
+}
+
+// BreakingReport classifies the changes between two versions of a document.
+type BreakingReport struct {
+	Breaking    []BreakingChange `json:"breaking,omitempty"`
+	NonBreaking []Change         `json:"nonBreaking,omitempty"`
+}
+
+// HasBreaking reports whether at least one change is breaking.
+func (r *BreakingReport) HasBreaking() bool {
+	return len(r.Breaking) > 0
+}
+
+// Err returns an error listing the breaking changes, or nil if there are none.
+// It eases failing a CI job when a change breaks the clients.
+func (r *BreakingReport) Err() error {
+	if !r.HasBreaking() {
+		return nil
+	}
+
+	errs := make([]error, 0, len(r.Breaking))
+	for _, c := range r.Breaking {
+		errs = append(errs, fmt.Errorf("%s (%s)", c.Change, strings.Join(c.Rules, ", ")))
+	}
+
+	return fmt.Errorf("%d breaking changes: %w", len(r.Breaking), errors.Join(errs...))
+}
+
+// CheckBreakingChanges compares two versions of a document and classifies their changes with the given rules.
+// If no rules are given, the DefaultBreakingRules are used.
+func CheckBreakingChanges(base, revision *OpenAPI, rules BreakingRules) (*BreakingReport, error) {
+	changes, err := Diff(base, revision)
+	if err != nil {
+		return nil, fmt.Errorf("diff: %w", err)
+	}
+
+	if rules == nil {
+		rules = DefaultBreakingRules()
+	}
+
+	report := &BreakingReport{}
+	for _, c := range changes {
+		var matches []string
+		for _, rule := range rules {
+			if rule.Match(c) {
+				matches = append(matches, rule.ID)
+			}
+		}
+
+		if len(matches) == 0 {
+			report.NonBreaking = append(report.NonBreaking, c)
+			continue
+		}
+
+		report.Breaking = append(report.Breaking, BreakingChange{Change: c, Rules: matches})
+	}
+
+	return report, nil
+}
+
+func operationRemoved(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if c.Kind != ChangeRemoved || len(tokens) < 2 || tokens[0] != "paths" {
+		return false
+	}
+
+	return len(tokens) == 2 || len(tokens) == 3 && containsString(pathItemMethods, tokens[2])
+}
+
+func responseRemoved(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+
+	return c.Kind == ChangeRemoved && len(tokens) == 5 && tokens[0] == "paths" && tokens[3] == "responses"
+}
+
+func parameterRequired(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	n := len(tokens)
+
+	switch {
+	case n >= 1 && tokens[n-1] == "parameters":
+		// The parameters of an operation or a path item have been added, or changed as a whole:
+		// a required parameter is new, or was optional.
+		old, _ := c.Old.([]any)
+		parameters, _ := c.New.([]any)
+		for _, p := range parameters {
+			if requiredParameter(p) && !hasRequiredParameter(old, p) {
+				return true
+			}
+		}
+		return false
+	case n >= 2 && tokens[n-2] == "parameters":
+		// A required parameter has been added.
+		return c.Kind == ChangeAdded && requiredParameter(c.New)
+	case n >= 3 && tokens[n-3] == "parameters" && tokens[n-1] == "required":
+		return c.New == true
+	default:
+		return false
+	}
+}
+
+// requiredParameter reports whether a parameter is required, as the path parameters are.
+func requiredParameter(parameter any) bool {
+	p, _ := parameter.(map[string]any)
+	return p["required"] == true || p["in"] == "path"
+}
+
+// hasRequiredParameter reports whether the parameters hold the same parameter, required.
+func hasRequiredParameter(parameters []any, parameter any) bool {
+	key, ok := elementKey(parameter)
+	if !ok {
+		return false
+	}
+
+	for _, p := range parameters {
+		if k, _ := elementKey(p); k == key && requiredParameter(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func requestBodyRequired(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	n := len(tokens)
+
+	switch {
+	case n >= 1 && tokens[n-1] == "requestBody":
+		body, _ := c.New.(map[string]any)
+		return c.Kind == ChangeAdded && body["required"] == true
+	case n >= 2 && tokens[n-2] == "requestBody" && tokens[n-1] == "required":
+		return c.New == true
+	default:
+		return false
+	}
+}
+
+func requestPropertyRequired(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if !isRequestChange(tokens) || len(tokens) == 0 || tokens[len(tokens)-1] != "required" {
+		return false
+	}
+
+	// The required keyword of a schema is an array, unlike the one of a parameter or a request body.
+	added, ok := c.New.([]any)
+	if !ok {
+		return false
+	}
+	old, _ := c.Old.([]any)
+
+	return len(missingValues(added, old)) > 0
+}
+
+func enumNarrowed(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if !isRequestChange(tokens) || len(tokens) == 0 || tokens[len(tokens)-1] != "enum" {
+		return false
+	}
+
+	switch c.Kind {
+	case ChangeAdded:
+		return true
+	case ChangeModified:
+		old, _ := c.Old.([]any)
+		values, _ := c.New.([]any)
+		return len(missingValues(old, values)) > 0
+	default:
+		return false
+	}
+}
+
+// upperBounds and lowerBounds list the schema constraints accepting less values when they decrease or increase.
+var (
+	upperBounds = []string{"maximum", "exclusiveMaximum", "maxItems", "maxLength"}
+	lowerBounds = []string{"minimum", "exclusiveMinimum", "minItems", "minLength"}
+)
+
+func constraintTightened(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if !isRequestChange(tokens) || len(tokens) == 0 {
+		return false
+	}
+
+	keyword := tokens[len(tokens)-1]
+	isUpper := containsString(upperBounds, keyword)
+	isLower := containsString(lowerBounds, keyword)
+
+	switch {
+	case keyword == "pattern":
+		return c.Kind == ChangeAdded || c.Kind == ChangeModified
+	case !isUpper && !isLower:
+		return false
+	case c.Kind == ChangeAdded:
+		// A false exclusive flag of OpenAPI 3.0 leaves the bound inclusive.
+		return c.New != false
+	case c.Kind == ChangeModified:
+		oldFlag, isOldFlag := c.Old.(bool)
+		flag, isFlag := c.New.(bool)
+		if isOldFlag || isFlag {
+			// An exclusive flag of OpenAPI 3.0 tightens the bound once set.
+			return flag && !oldFlag
+		}
+
+		old, okOld := c.Old.(float64)
+		value, okNew := c.New.(float64)
+		if !okOld || !okNew {
+			return false
+		}
+		return isUpper && value < old || isLower && value > old
+	default:
+		return false
+	}
+}
+
+func typeChanged(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if c.Kind != ChangeModified || len(tokens) == 0 {
+		return false
+	}
+
+	keyword := tokens[len(tokens)-1]
+
+	return (keyword == "type" || keyword == "format") && !isPropertyName(tokens)
+}
+
+func responsePropertyRemoved(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	n := len(tokens)
+
+	return c.Kind == ChangeRemoved && n >= 2 && tokens[n-2] == "properties" && containsString(tokens, "responses")
+}
+
+func securityChanged(c Change) bool {
+	tokens := splitPointer(c.Pointer)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	switch {
+	case tokens[0] == "security":
+		return true
+	case tokens[0] == "components":
+		return len(tokens) > 1 && tokens[1] == "securitySchemes"
+	case tokens[0] == "paths":
+		return len(tokens) > 3 && tokens[3] == "security"
+	default:
+		return false
+	}
+}
+
+// isRequestChange reports whether the pointer targets a part of an operation request.
+func isRequestChange(tokens []string) bool {
+	return !containsString(tokens, "responses") &&
+		(containsString(tokens, "parameters") || containsString(tokens, "requestBody"))
+}
+
+// isPropertyName reports whether the last token of the pointer is the name of a schema property.
+func isPropertyName(tokens []string) bool {
+	n := len(tokens)
+
+	return n >= 2 && tokens[n-2] == "properties"
+}
+
+// missingValues returns the values that are not in the given set.
+func missingValues(values, set []any) []any {
+	var missing []any
+	for _, v := range values {
+		found := false
+		for _, s := range set {
+			if reflect.DeepEqual(v, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, v)
+		}
+	}
+
+	return missing
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,224 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBreakingChanges(t *testing.T) {
+	tests := []struct {
+		desc     string
+		setup    func(oas *OpenAPI)
+		update   func(oas *OpenAPI)
+		rules    BreakingRules
+		expected map[string][]string
+	}{
+		{
+			desc:     "no change",
+			update:   func(oas *OpenAPI) {},
+			expected: map[string][]string{},
+		},
+		{
+			desc: "removed operation",
+			update: func(oas *OpenAPI) {
+				delete(oas.Paths, "/pets/{petId}")
+			},
+			expected: map[string][]string{
+				"/paths/~1pets~1{petId}": {"operation-removed"},
+			},
+		},
+		{
+			desc: "removed response code",
+			update: func(oas *OpenAPI) {
+				delete(*oas.Paths["/pets"].Post.Responses, "201")
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/post/responses/201": {"response-removed"},
+			},
+		},
+		{
+			desc: "new required parameter",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Parameters[0].Required = boolPtr(true)
+				oas.Paths["/pets"].Get.Parameters = append(oas.Paths["/pets"].Get.Parameters, Parameter{
+					Name: "name", In: "query", Required: boolPtr(true),
+				})
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/get/parameters/0/required": {"parameter-required"},
+				"/paths/~1pets/get/parameters/1":          {"parameter-required"},
+			},
+		},
+		{
+			desc: "first required parameter",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Post.Parameters = []Parameter{{Name: "name", In: "query", Required: boolPtr(true)}}
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/post/parameters": {"parameter-required"},
+			},
+		},
+		{
+			desc: "first path item parameter",
+			update: func(oas *OpenAPI) {
+				pathItem := oas.Paths["/pets/{petId}"]
+				pathItem.Parameters = []Parameter{{Name: "petId", In: "path"}}
+				oas.Paths["/pets/{petId}"] = pathItem
+			},
+			expected: map[string][]string{
+				"/paths/~1pets~1{petId}/parameters": {"parameter-required"},
+			},
+		},
+		{
+			desc: "first optional parameter",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Post.Parameters = []Parameter{{Name: "name", In: "query"}}
+			},
+			expected: map[string][]string{},
+		},
+		{
+			desc: "tightened request constraints",
+			update: func(oas *OpenAPI) {
+				schema := oas.Paths["/pets"].Get.Parameters[0].Schema
+				schema.Maximum = float64Ptr(50)
+				schema.Enum = []any{10.0, 20.0}
+				schema.Type = "number"
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/get/parameters/0/schema/enum":    {"enum-narrowed"},
+				"/paths/~1pets/get/parameters/0/schema/maximum": {"constraint-tightened"},
+				"/paths/~1pets/get/parameters/0/schema/type":    {"type-changed"},
+			},
+		},
+		{
+			desc: "relaxed request constraint",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Parameters[0].Schema.Maximum = float64Ptr(200)
+			},
+			expected: map[string][]string{},
+		},
+		{
+			desc: "exclusive bound flags set",
+			setup: func(oas *OpenAPI) {
+				schema := oas.Paths["/pets"].Get.Parameters[0].Schema
+				schema.Minimum = float64Ptr(1)
+				schema.ExclusiveMaximum = false
+			},
+			update: func(oas *OpenAPI) {
+				schema := oas.Paths["/pets"].Get.Parameters[0].Schema
+				schema.ExclusiveMinimum = true
+				schema.ExclusiveMaximum = true
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/get/parameters/0/schema/exclusiveMinimum": {"constraint-tightened"},
+				"/paths/~1pets/get/parameters/0/schema/exclusiveMaximum": {"constraint-tightened"},
+			},
+		},
+		{
+			desc: "exclusive bound flags cleared",
+			setup: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Parameters[0].Schema.ExclusiveMaximum = true
+			},
+			update: func(oas *OpenAPI) {
+				schema := oas.Paths["/pets"].Get.Parameters[0].Schema
+				schema.ExclusiveMinimum = false
+				schema.ExclusiveMaximum = false
+			},
+			expected: map[string][]string{},
+		},
+		{
+			desc: "new required request body",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Post.RequestBody = &RequestBody{
+					Required: true,
+					Content: map[string]MediaType{
+						"application/json": {Schema: &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Pet"}}},
+					},
+				}
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/post/requestBody": {"request-body-required"},
+			},
+		},
+		{
+			desc: "new required request property",
+			setup: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Post.RequestBody = &RequestBody{
+					Content: map[string]MediaType{
+						"application/json": {Schema: &Schema{JSONSchema: JSONSchema{
+							Type:       "object",
+							Properties: map[string]JSONSchema{"name": {Type: "string"}},
+						}}},
+					},
+				}
+			},
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Post.RequestBody.Content["application/json"].Schema.Required = []string{"name"}
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/post/requestBody/content/application~1json/schema/required": {"request-property-required"},
+			},
+		},
+		{
+			desc: "removed response property",
+			update: func(oas *OpenAPI) {
+				delete(oas.Components.Schemas["Pet"].Properties, "tag")
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/tag":    {"response-property-removed"},
+				"/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/properties/tag": {"response-property-removed"},
+			},
+		},
+		{
+			desc: "operation security scopes changed",
+			setup: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Security = []SecurityRequirement{{"oauth": {"read:pets"}}}
+			},
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Security = []SecurityRequirement{{"oauth": {"read:pets", "admin"}}}
+			},
+			expected: map[string][]string{
+				"/paths/~1pets/get/security": {"security-changed"},
+			},
+		},
+		{
+			desc: "disabled rule",
+			update: func(oas *OpenAPI) {
+				delete(oas.Paths, "/pets/{petId}")
+			},
+			rules:    DefaultBreakingRules().Without("operation-removed"),
+			expected: map[string][]string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			base, err := FromFile("testdata/petstore.yaml")
+			require.NoError(t, err)
+
+			revision, err := FromFile("testdata/petstore.yaml")
+			require.NoError(t, err)
+
+			if test.setup != nil {
+				test.setup(base)
+				test.setup(revision)
+			}
+			test.update(revision)
+
+			report, err := CheckBreakingChanges(base, revision, test.rules)
+			require.NoError(t, err)
+
+			breaking := map[string][]string{}
+			for _, c := range report.Breaking {
+				breaking[c.Pointer] = c.Rules
+			}
+			assert.Equal(t, test.expected, breaking)
+			assert.Equal(t, len(test.expected) > 0, report.Err() != nil)
+		})
+	}
+}
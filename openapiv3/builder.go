@@ -0,0 +1,479 @@
+package openapiv3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DocumentBuilder builds an OpenAPI document with a fluent API:
+//
+//	doc, err := NewDocument().
+//		Info("Pets", "1.0.0").
+//		SchemaComponent("Pet", petSchema).
+//		Path("/pets/{petId}").
+//		Get("showPetById").
+//		Param("path", "petId", &Schema{JSONSchema: JSONSchema{Type: "integer"}}).
+//		Response(200, "", SchemaRef("Pet")).
+//		Build()
+//
+// The builders of the paths and operations give access to the methods of their parents,
+// for a chain to declare several operations and paths.
+// The defaults of the specification are filled: the version of the document is 3.1.0, the path parameters
+// are required, the bodies are JSON and the responses are described by their status text when no description
+// is given.
+// The local references of the document are checked by Build, along with the document.
+type DocumentBuilder struct {
+	doc  *OpenAPI
+	errs []error
+}
+
+// PathBuilder builds a path item of a document, see DocumentBuilder.
+type PathBuilder struct {
+	*DocumentBuilder
+
+	path string
+}
+
+// OperationBuilder builds an operation of a path, see DocumentBuilder.
+type OperationBuilder struct {
+	*PathBuilder
+
+	operation *Operation
+}
+
+// NewDocument returns a DocumentBuilder of an empty document.
+func NewDocument() *DocumentBuilder {
+	return &DocumentBuilder{doc: &OpenAPI{Openapi: "3.1.0", Paths: Paths{}}}
+}
+
+// SchemaRef returns a schema referencing a component of the schemas.
+func SchemaRef(name string) *Schema {
+	return &Schema{JSONSchema: JSONSchema{Ref: componentRef("schemas", name)}}
+}
+
+// componentRef returns the local reference to a component.
+func componentRef(field, name string) string {
+	return "#" + appendPointer("/components", field, name)
+}
+
+// Info sets the title and the version of the API.
+func (b *DocumentBuilder) Info(title, version string) *DocumentBuilder {
+	b.doc.Info.Title = title
+	b.doc.Info.Version = version
+
+	return b
+}
+
+// Description sets the description of the API.
+func (b *DocumentBuilder) Description(description string) *DocumentBuilder {
+	b.doc.Info.Description = description
+
+	return b
+}
+
+// Server adds a server of the API.
+func (b *DocumentBuilder) Server(url, description string) *DocumentBuilder {
+	b.doc.Servers = append(b.doc.Servers, Server{URL: url, Description: description})
+
+	return b
+}
+
+// Tag declares a tag of the operations.
+func (b *DocumentBuilder) Tag(name, description string) *DocumentBuilder {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+
+	return b
+}
+
+// Security adds a security requirement of the API, with the scopes required for each scheme.
+// The schemes must be declared with SecurityScheme.
+func (b *DocumentBuilder) Security(requirement SecurityRequirement) *DocumentBuilder {
+	b.doc.Security = append(b.doc.Security, securityRequirement(requirement))
+
+	return b
+}
+
+// securityRequirement returns a requirement, an empty one, making the security optional, if nil.
+func securityRequirement(requirement SecurityRequirement) SecurityRequirement {
+	if requirement == nil {
+		return SecurityRequirement{}
+	}
+
+	return requirement
+}
+
+// SecurityScheme declares a component of the security schemes.
+func (b *DocumentBuilder) SecurityScheme(name string, scheme SecurityScheme) *DocumentBuilder {
+	b.components().SecuritySchemes = addComponent(b, b.components().SecuritySchemes, "securitySchemes", name, scheme)
+
+	return b
+}
+
+// SchemaComponent declares a component of the schemas, referenced with SchemaRef.
+func (b *DocumentBuilder) SchemaComponent(name string, schema Schema) *DocumentBuilder {
+	b.components().Schemas = addComponent(b, b.components().Schemas, "schemas", name, schema)
+
+	return b
+}
+
+// ParameterComponent declares a component of the parameters, referenced with OperationBuilder.ParamRef.
+// A path parameter is required.
+func (b *DocumentBuilder) ParameterComponent(name string, parameter Parameter) *DocumentBuilder {
+	if parameter.In == "path" {
+		required := true
+		parameter.Required = &required
+	}
+	b.components().Parameters = addComponent(b, b.components().Parameters, "parameters", name, parameter)
+
+	return b
+}
+
+// ResponseComponent declares a component of the responses, referenced with OperationBuilder.ResponseRef.
+func (b *DocumentBuilder) ResponseComponent(name string, response Response) *DocumentBuilder {
+	b.components().Responses = addComponent(b, b.components().Responses, "responses", name, response)
+
+	return b
+}
+
+// RequestBodyComponent declares a component of the request bodies, referenced with OperationBuilder.BodyRef.
+func (b *DocumentBuilder) RequestBodyComponent(name string, requestBody RequestBody) *DocumentBuilder {
+	b.components().RequestBodies = addComponent(b, b.components().RequestBodies, "requestBodies", name, requestBody)
+
+	return b
+}
+
+func (b *DocumentBuilder) components() *Components {
+	if b.doc.Components == nil {
+		b.doc.Components = &Components{}
+	}
+
+	return b.doc.Components
+}
+
+// addComponent adds a component to the components of a kind, failing if the name is already used.
+func addComponent[V any](b *DocumentBuilder, components map[string]V, field, name string, component V) map[string]V {
+	if components == nil {
+		components = map[string]V{}
+	}
+	if _, ok := components[name]; ok {
+		b.errs = append(b.errs, fmt.Errorf("component %s %q already declared", field, name))
+		return components
+	}
+	components[name] = component
+
+	return components
+}
+
+// Path returns the builder of a path, like /pets/{petId}.
+func (b *DocumentBuilder) Path(path string) *PathBuilder {
+	if !strings.HasPrefix(path, "/") {
+		b.errs = append(b.errs, fmt.Errorf("path %q must start with /", path))
+	}
+	if _, ok := b.doc.Paths[path]; !ok {
+		b.doc.Paths[path] = PathItem{}
+	}
+
+	return &PathBuilder{DocumentBuilder: b, path: path}
+}
+
+// Build returns the document, once validated.
+// Besides the validation of the document, the local references and the schemes of the security requirements
+// must resolve, the parameters of the path templates must be declared and the operation ids must be unique.
+func (b *DocumentBuilder) Build() (*OpenAPI, error) {
+	errs := append([]error(nil), b.errs...)
+
+	if err := b.doc.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, b.unresolvedRefs()...)
+
+	operationIDs := map[string]string{}
+	for _, path := range sortedKeys(b.doc.Paths) {
+		pathItem := b.doc.Paths[path]
+		_, names := pathPattern(path)
+		for _, mo := range pathItem.Operations() {
+			location := fmt.Sprintf("%s %q", mo.Method, path)
+
+			if id := mo.Operation.OperationID; id != "" {
+				if other, ok := operationIDs[id]; ok {
+					errs = append(errs, fmt.Errorf("%s: operationId %q already used by %s", location, id, other))
+				}
+				operationIDs[id] = location
+			}
+
+			ep, err := b.doc.EffectiveParameters(path, mo.Method)
+			if err != nil {
+				// The unresolved references are already reported.
+				continue
+			}
+			for _, name := range names {
+				if _, ok := ep.Get("path", name); !ok {
+					errs = append(errs, fmt.Errorf("%s: missing path parameter %q", location, name))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return b.doc, nil
+}
+
+// unresolvedRefs returns the errors of the local references and the schemes of the security requirements
+// which do not resolve.
+func (b *DocumentBuilder) unresolvedRefs() []error {
+	var errs []error
+	check := func(node *WalkNode, ref string) {
+		if !strings.HasPrefix(ref, "#") {
+			return
+		}
+		if _, err := b.doc.Lookup(strings.TrimPrefix(ref, "#")); err != nil {
+			errs = append(errs, fmt.Errorf("%s: unresolved reference %q", node.Pointer, ref))
+		}
+	}
+
+	w := &Walker{Enter: func(node *WalkNode) error {
+		switch v := node.Value.(type) {
+		case *PathItem:
+			check(node, v.Ref)
+		case *Parameter:
+			check(node, v.Ref)
+		case *Header:
+			check(node, v.Ref)
+		case *RequestBody:
+			check(node, v.Ref)
+		case *Response:
+			check(node, v.Ref)
+		case *Example:
+			check(node, v.Ref)
+		case *Link:
+			check(node, v.Ref)
+		case *Schema:
+			check(node, v.Ref)
+		case *JSONSchema:
+			check(node, v.Ref)
+		case *SecurityRequirement:
+			for _, scheme := range sortedKeys(*v) {
+				if _, ok := b.components().SecuritySchemes[scheme]; !ok {
+					errs = append(errs, fmt.Errorf("%s: undeclared security scheme %q", node.Pointer, scheme))
+				}
+			}
+		}
+		return nil
+	}}
+	// The hook does not fail.
+	_ = w.Walk(b.doc)
+
+	return errs
+}
+
+// Param adds a parameter to all the operations of the path.
+func (b *PathBuilder) Param(in, name string, schema *Schema) *PathBuilder {
+	b.updatePathItem(func(pi *PathItem) {
+		pi.Parameters = append(pi.Parameters, newParameter(in, name, schema))
+	})
+
+	return b
+}
+
+// updatePathItem applies a change to the path item, which is held by value in the paths.
+func (b *PathBuilder) updatePathItem(update func(pi *PathItem)) {
+	pathItem := b.doc.Paths[b.path]
+	update(&pathItem)
+	b.doc.Paths[b.path] = pathItem
+}
+
+// Get returns the builder of the GET operation of the path.
+func (b *PathBuilder) Get(operationID string) *OperationBuilder {
+	return b.operation(http.MethodGet, operationID)
+}
+
+// Put returns the builder of the PUT operation of the path.
+func (b *PathBuilder) Put(operationID string) *OperationBuilder {
+	return b.operation(http.MethodPut, operationID)
+}
+
+// Post returns the builder of the POST operation of the path.
+func (b *PathBuilder) Post(operationID string) *OperationBuilder {
+	return b.operation(http.MethodPost, operationID)
+}
+
+// Delete returns the builder of the DELETE operation of the path.
+func (b *PathBuilder) Delete(operationID string) *OperationBuilder {
+	return b.operation(http.MethodDelete, operationID)
+}
+
+// Options returns the builder of the OPTIONS operation of the path.
+func (b *PathBuilder) Options(operationID string) *OperationBuilder {
+	return b.operation(http.MethodOptions, operationID)
+}
+
+// Head returns the builder of the HEAD operation of the path.
+func (b *PathBuilder) Head(operationID string) *OperationBuilder {
+	return b.operation(http.MethodHead, operationID)
+}
+
+// Patch returns the builder of the PATCH operation of the path.
+func (b *PathBuilder) Patch(operationID string) *OperationBuilder {
+	return b.operation(http.MethodPatch, operationID)
+}
+
+// Trace returns the builder of the TRACE operation of the path.
+func (b *PathBuilder) Trace(operationID string) *OperationBuilder {
+	return b.operation(http.MethodTrace, operationID)
+}
+
+// operation returns the builder of an operation of the path, creating the operation if needed.
+func (b *PathBuilder) operation(method, operationID string) *OperationBuilder {
+	pathItem := b.doc.Paths[b.path]
+	field := pathItem.operationField(strings.ToLower(method))
+	if *field == nil {
+		*field = &Operation{OperationID: operationID}
+		b.doc.Paths[b.path] = pathItem
+	} else if operationID != "" {
+		(*field).OperationID = operationID
+	}
+
+	return &OperationBuilder{PathBuilder: b, operation: *field}
+}
+
+// Summary sets the summary of the operation.
+func (b *OperationBuilder) Summary(summary string) *OperationBuilder {
+	b.operation.Summary = summary
+
+	return b
+}
+
+// Description sets the description of the operation.
+func (b *OperationBuilder) Description(description string) *OperationBuilder {
+	b.operation.Description = description
+
+	return b
+}
+
+// Tags adds tags to the operation.
+func (b *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	b.operation.Tags = append(b.operation.Tags, tags...)
+
+	return b
+}
+
+// Deprecated declares the operation deprecated.
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.operation.Deprecated = true
+
+	return b
+}
+
+// Security adds a security requirement of the operation, overriding the ones of the document.
+// A nil requirement makes the security optional.
+func (b *OperationBuilder) Security(requirement SecurityRequirement) *OperationBuilder {
+	b.operation.Security = append(b.operation.Security, securityRequirement(requirement))
+
+	return b
+}
+
+// Param adds an optional parameter to the operation, required if it is a path parameter.
+func (b *OperationBuilder) Param(in, name string, schema *Schema) *OperationBuilder {
+	b.operation.Parameters = append(b.operation.Parameters, newParameter(in, name, schema))
+
+	return b
+}
+
+// RequiredParam adds a required parameter to the operation.
+func (b *OperationBuilder) RequiredParam(in, name string, schema *Schema) *OperationBuilder {
+	p := newParameter(in, name, schema)
+	required := true
+	p.Required = &required
+	b.operation.Parameters = append(b.operation.Parameters, p)
+
+	return b
+}
+
+// ParamRef adds a parameter referencing a component of the parameters.
+func (b *OperationBuilder) ParamRef(name string) *OperationBuilder {
+	b.operation.Parameters = append(b.operation.Parameters, Parameter{Reference: Reference{Ref: componentRef("parameters", name)}})
+
+	return b
+}
+
+// newParameter returns a parameter, required if it is a path parameter.
+func newParameter(in, name string, schema *Schema) Parameter {
+	p := Parameter{Name: name, In: in, Schema: schema}
+	if in == "path" {
+		required := true
+		p.Required = &required
+	}
+
+	return p
+}
+
+// Body sets the required JSON request body of the operation.
+func (b *OperationBuilder) Body(schema *Schema) *OperationBuilder {
+	return b.BodyContent("application/json", schema)
+}
+
+// BodyContent sets the required request body of the operation, of the given media type.
+func (b *OperationBuilder) BodyContent(contentType string, schema *Schema) *OperationBuilder {
+	b.operation.RequestBody = &RequestBody{
+		Content:  map[string]MediaType{contentType: {Schema: schema}},
+		Required: true,
+	}
+
+	return b
+}
+
+// BodyRef sets the request body of the operation, referencing a component of the request bodies.
+func (b *OperationBuilder) BodyRef(name string) *OperationBuilder {
+	b.operation.RequestBody = &RequestBody{Reference: Reference{Ref: componentRef("requestBodies", name)}}
+
+	return b
+}
+
+// Response adds a response of a status code to the operation, with a JSON body if the schema is not nil.
+// The description defaults to the status text.
+func (b *OperationBuilder) Response(status int, description string, schema *Schema) *OperationBuilder {
+	if description == "" {
+		description = http.StatusText(status)
+	}
+
+	return b.response(strconv.Itoa(status), description, schema)
+}
+
+// DefaultResponse adds the default response to the operation, with a JSON body if the schema is not nil.
+func (b *OperationBuilder) DefaultResponse(description string, schema *Schema) *OperationBuilder {
+	if description == "" {
+		description = "Default response"
+	}
+
+	return b.response("default", description, schema)
+}
+
+func (b *OperationBuilder) response(code, description string, schema *Schema) *OperationBuilder {
+	response := Response{Description: description}
+	if schema != nil {
+		response.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	b.setResponse(code, response)
+
+	return b
+}
+
+// ResponseRef adds a response of a status code to the operation, referencing a component of the responses.
+func (b *OperationBuilder) ResponseRef(status int, name string) *OperationBuilder {
+	b.setResponse(strconv.Itoa(status), Response{Reference: Reference{Ref: componentRef("responses", name)}})
+
+	return b
+}
+
+func (b *OperationBuilder) setResponse(code string, response Response) {
+	if b.operation.Responses == nil {
+		b.operation.Responses = &Responses{}
+	}
+	(*b.operation.Responses)[code] = response
+}
@@ -0,0 +1,157 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBuilder_Build(t *testing.T) {
+	integer := &Schema{JSONSchema: JSONSchema{Type: "integer"}}
+
+	doc, err := NewDocument().
+		Info("Pets", "1.0.0").
+		Server("https://api.example.com", "").
+		Tag("pets", "Everything about pets").
+		SecurityScheme("bearerAuth", SecurityScheme{Type: "http", Scheme: "bearer"}).
+		Security(SecurityRequirement{"bearerAuth": nil}).
+		SchemaComponent("Pet", Schema{JSONSchema: JSONSchema{Type: "object", Required: []string{"name"}}}).
+		ResponseComponent("NotFound", Response{Description: "Not found"}).
+		ParameterComponent("PetID", Parameter{Name: "petId", In: "path", Schema: integer}).
+		Path("/pets").
+		Get("listPets").
+		Summary("List the pets").
+		Tags("pets").
+		Param("query", "limit", integer).
+		Response(200, "", &Schema{JSONSchema: JSONSchema{Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/Pet"}}}).
+		Post("createPet").
+		Security(nil).
+		Body(SchemaRef("Pet")).
+		Response(201, "Created pet", SchemaRef("Pet")).
+		Path("/pets/{petId}").
+		Param("path", "petId", integer).
+		Get("showPetById").
+		RequiredParam("header", "X-Request-ID", nil).
+		Response(200, "", SchemaRef("Pet")).
+		ResponseRef(404, "NotFound").
+		DefaultResponse("", nil).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.1.0", doc.Openapi)
+	assert.Equal(t, []SecurityRequirement{{"bearerAuth": nil}}, doc.Security)
+
+	list := doc.Paths["/pets"].Get
+	require.NotNil(t, list)
+	assert.Equal(t, &Operation{
+		OperationID: "listPets",
+		Summary:     "List the pets",
+		Tags:        []string{"pets"},
+		Parameters:  []Parameter{{Name: "limit", In: "query", Schema: integer}},
+		Responses: &Responses{"200": {
+			Description: "OK",
+			Content: map[string]MediaType{"application/json": {
+				Schema: &Schema{JSONSchema: JSONSchema{Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/Pet"}}},
+			}},
+		}},
+	}, list)
+
+	create := doc.Paths["/pets"].Post
+	require.NotNil(t, create)
+	assert.Equal(t, []SecurityRequirement{{}}, create.Security)
+	assert.Equal(t, &RequestBody{
+		Content:  map[string]MediaType{"application/json": {Schema: SchemaRef("Pet")}},
+		Required: true,
+	}, create.RequestBody)
+
+	assert.Equal(t, []Parameter{{Name: "petId", In: "path", Required: boolPtr(true), Schema: integer}}, doc.Paths["/pets/{petId}"].Parameters)
+
+	show := doc.Paths["/pets/{petId}"].Get
+	require.NotNil(t, show)
+	assert.Equal(t, []Parameter{{Name: "X-Request-ID", In: "header", Required: boolPtr(true)}}, show.Parameters)
+	assert.Equal(t, Responses{
+		"200": {
+			Description: "OK",
+			Content:     map[string]MediaType{"application/json": {Schema: SchemaRef("Pet")}},
+		},
+		"404":     {Reference: Reference{Ref: "#/components/responses/NotFound"}},
+		"default": {Description: "Default response"},
+	}, *show.Responses)
+
+	assert.Equal(t, boolPtr(true), doc.Components.Parameters["PetID"].Required)
+}
+
+func TestDocumentBuilder_Build_errors(t *testing.T) {
+	tests := []struct {
+		desc           string
+		builder        *DocumentBuilder
+		expectedErrors []string
+	}{
+		{
+			desc:           "missing info",
+			builder:        NewDocument(),
+			expectedErrors: []string{"invalid Info: title is required"},
+		},
+		{
+			desc: "unresolved references",
+			builder: NewDocument().Info("Pets", "1.0.0").
+				Path("/pets").
+				Get("listPets").
+				Security(SecurityRequirement{"apiKey": nil}).
+				ParamRef("Limit").
+				Response(200, "", SchemaRef("Pets")).
+				ResponseRef(404, "NotFound").
+				DocumentBuilder,
+			expectedErrors: []string{
+				`/paths/~1pets/get/parameters/0: unresolved reference "#/components/parameters/Limit"`,
+				`/paths/~1pets/get/responses/200/content/application~1json/schema: unresolved reference "#/components/schemas/Pets"`,
+				`/paths/~1pets/get/responses/404: unresolved reference "#/components/responses/NotFound"`,
+				`/paths/~1pets/get/security/0: undeclared security scheme "apiKey"`,
+			},
+		},
+		{
+			desc: "missing path parameter",
+			builder: NewDocument().Info("Pets", "1.0.0").
+				Path("/pets/{petId}").
+				Get("showPetById").
+				DocumentBuilder,
+			expectedErrors: []string{`GET "/pets/{petId}": missing path parameter "petId"`},
+		},
+		{
+			desc: "duplicated operation id",
+			builder: NewDocument().Info("Pets", "1.0.0").
+				Path("/pets").Get("pets").
+				Path("/dogs").Get("pets").
+				DocumentBuilder,
+			expectedErrors: []string{`GET "/pets": operationId "pets" already used by GET "/dogs"`},
+		},
+		{
+			desc: "duplicated component",
+			builder: NewDocument().Info("Pets", "1.0.0").
+				SchemaComponent("Pet", Schema{}).
+				SchemaComponent("Pet", Schema{}),
+			expectedErrors: []string{`component schemas "Pet" already declared`},
+		},
+		{
+			desc:           "relative path",
+			builder:        NewDocument().Info("Pets", "1.0.0").Path("pets").DocumentBuilder,
+			expectedErrors: []string{`path "pets" must start with /`},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := test.builder.Build()
+			require.Error(t, err)
+			assert.Nil(t, doc)
+
+			for _, expected := range test.expectedErrors {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
@@ -141,4 +141,6 @@ type Components struct {
 	Callbacks map[string]Callback `json:"callbacks,omitempty"`
 	// An object to hold reusable Path Item Object.
 	PathItems map[string]PathItem `json:"pathItems,omitempty"`
+	// Extensions are the Specification Extensions of the components.
+	Extensions Extensions `json:"-"`
 }
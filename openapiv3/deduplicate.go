@@ -0,0 +1,230 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DeduplicateOptions configures OpenAPI.DeduplicateSchemas.
+type DeduplicateOptions struct {
+	// IgnoreDescriptions considers the schemas differing only by their top-level descriptions as identical.
+	// The description of an occurrence differing from the one of the component schema is kept next to its reference.
+	// The schemas whose subschemas have different descriptions still differ, as these descriptions would be lost.
+	IgnoreDescriptions bool
+	// MinOccurrences is the number of occurrences from which an inline schema is hoisted, 2 by default.
+	// An inline schema identical to a component schema is always replaced.
+	MinOccurrences int
+}
+
+// schemaOccurrence is a schema of the document, compared by DeduplicateSchemas.
+type schemaOccurrence struct {
+	pointer string
+	schema  Schema
+}
+
+// DeduplicateSchemas replaces the inline object and composed schemas, having properties or subschemas,
+// appearing several times, with a reference to a component schema, and returns the local references
+// of the component schemas it added, in order.
+//   - An inline schema identical to a component schema is replaced with a reference to it.
+//   - Otherwise, the first occurrence is hoisted into the component schemas, named after its title in
+//     upper camel case, e.g. PetOwner for "pet owner", or with a generated name, e.g. Schema1, and suffixed
+//     with a number if the name is already used, e.g. PetOwner2.
+//   - The outermost schemas are deduplicated first, so that the duplicates they contain are counted once,
+//     and the duplicates within the component schemas are replaced as well.
+func (o *OpenAPI) DeduplicateSchemas(options DeduplicateOptions) ([]string, error) {
+	minOccurrences := options.MinOccurrences
+	if minOccurrences <= 0 {
+		minOccurrences = 2
+	}
+
+	var added []string
+	for {
+		occurrences, components, err := o.schemaOccurrences(options.IgnoreDescriptions)
+		if err != nil {
+			return added, err
+		}
+
+		var keys []string
+		for key, inline := range occurrences {
+			if _, ok := components[key]; ok || len(inline) >= minOccurrences {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return added, nil
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) > len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+
+		key := keys[0]
+		component, ok := components[key]
+		if !ok {
+			if o.Components == nil {
+				o.Components = &Components{}
+			}
+			if o.Components.Schemas == nil {
+				o.Components.Schemas = map[string]Schema{}
+			}
+			component = occurrences[key][0]
+			name := o.hoistedSchemaName(component.schema)
+			o.Components.Schemas[name] = component.schema
+			component.pointer = appendPointer("/components/schemas", name)
+			added = append(added, "#"+component.pointer)
+		}
+
+		if err = o.replaceSchemas(occurrences[key], component); err != nil {
+			return added, err
+		}
+	}
+}
+
+// schemaOccurrences returns the inline object and composed schemas of the document by key,
+// and the first component schema of each key, the key being the JSON representation of the schema,
+// without its top-level description if the descriptions are ignored.
+func (o *OpenAPI) schemaOccurrences(ignoreDescriptions bool) (map[string][]schemaOccurrence, map[string]schemaOccurrence, error) {
+	occurrences := map[string][]schemaOccurrence{}
+	components := map[string]schemaOccurrence{}
+
+	w := &Walker{
+		Enter: func(node *WalkNode) error {
+			var s *JSONSchema
+			switch v := node.Value.(type) {
+			case *Schema:
+				s = &v.JSONSchema
+			case *JSONSchema:
+				s = v
+			default:
+				return nil
+			}
+			if s.Ref != "" {
+				return nil
+			}
+
+			// The schema is encoded from its tree for the maps, such as the additional properties,
+			// and the structs of the model to have the same representation.
+			tree, err := toTree(node.Value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", node.Pointer, err)
+			}
+			occurrence := schemaOccurrence{pointer: node.Pointer}
+			if err = fromTree(tree, &occurrence.schema); err != nil {
+				return fmt.Errorf("%s: %w", node.Pointer, err)
+			}
+			if m, ok := tree.(map[string]any); ok && ignoreDescriptions {
+				delete(m, "description")
+			}
+			raw, err := json.Marshal(tree)
+			if err != nil {
+				return fmt.Errorf("%s: marshal schema: %w", node.Pointer, err)
+			}
+			key := string(raw)
+
+			if isComponentSchema(node.Pointer) {
+				if _, ok := components[key]; !ok {
+					components[key] = occurrence
+				}
+				return nil
+			}
+
+			if len(s.Properties) > 0 || len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+				occurrences[key] = append(occurrences[key], occurrence)
+			}
+			return nil
+		},
+	}
+	if err := w.Walk(o); err != nil {
+		return nil, nil, err
+	}
+
+	return occurrences, components, nil
+}
+
+// replaceSchemas replaces the occurrences of a schema with a reference to a component schema,
+// keeping their description if it differs from the one of the component.
+func (o *OpenAPI) replaceSchemas(occurrences []schemaOccurrence, component schemaOccurrence) error {
+	descriptions := map[string]string{}
+	for _, occurrence := range occurrences {
+		descriptions[occurrence.pointer] = occurrence.schema.Description
+	}
+
+	ref := JSONSchema{Ref: "#" + component.pointer}
+	w := &Walker{
+		Enter: func(node *WalkNode) error {
+			description, ok := descriptions[node.Pointer]
+			if !ok {
+				return nil
+			}
+
+			ref := ref
+			if description != component.schema.Description {
+				ref.Description = description
+			}
+
+			switch v := node.Value.(type) {
+			case *Schema:
+				*v = Schema{JSONSchema: ref}
+			case *JSONSchema:
+				*v = ref
+			default:
+				return nil
+			}
+			return SkipNode
+		},
+	}
+
+	return w.Walk(o)
+}
+
+// hoistedSchemaName returns the name of a schema hoisted into the component schemas, not used yet,
+// derived from its title or generated.
+func (o *OpenAPI) hoistedSchemaName(schema Schema) string {
+	used := func(name string) bool {
+		_, ok := o.Components.Schemas[name]
+		return ok
+	}
+
+	base := titleComponentName(schema.Title)
+	if base == "" {
+		for i := 1; ; i++ {
+			if name := fmt.Sprintf("Schema%d", i); !used(name) {
+				return name
+			}
+		}
+	}
+
+	name := base
+	for i := 2; used(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+
+	return name
+}
+
+// titleComponentName converts a title into a component name in upper camel case, e.g. PetOwner for "pet owner",
+// made of the letters and the digits of the title.
+func titleComponentName(title string) string {
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+
+	return b.String()
+}
+
+// isComponentSchema reports whether a pointer is the one of a component schema.
+func isComponentSchema(ptr string) bool {
+	tokens := splitPointer(ptr)
+	return len(tokens) == 3 && tokens[0] == "components" && tokens[1] == "schemas"
+}
@@ -0,0 +1,153 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_DeduplicateSchemas(t *testing.T) {
+	ref := func(ref, description string) *Schema {
+		return &Schema{JSONSchema: JSONSchema{Ref: ref, Description: description}}
+	}
+	page := func(description string) Schema {
+		return Schema{JSONSchema: JSONSchema{
+			Title:       "page",
+			Description: description,
+			Type:        "object",
+			Properties:  map[string]JSONSchema{"next": {Type: "string"}},
+		}}
+	}
+	inlinePage := page("A page of owners.")
+	filter := &Schema{JSONSchema: JSONSchema{Type: "object", Properties: map[string]JSONSchema{"name": {Type: "string"}}}}
+
+	tests := []struct {
+		desc    string
+		options DeduplicateOptions
+		added   []string
+		schemas map[string]*Schema
+		page    *Schema
+	}{
+		{
+			desc:  "identical schemas",
+			added: []string{"#/components/schemas/Page2", "#/components/schemas/Schema1"},
+			schemas: map[string]*Schema{
+				"/owners":  &inlinePage,
+				"/pets":    ref("#/components/schemas/Page2", ""),
+				"/stores":  ref("#/components/schemas/Page2", ""),
+				"filter":   ref("#/components/schemas/Schema1", ""),
+				"/default": ref("#/components/schemas/Error", ""),
+			},
+			page: func() *Schema { s := page("A page of pets."); return &s }(),
+		},
+		{
+			desc:    "ignoring descriptions",
+			options: DeduplicateOptions{IgnoreDescriptions: true},
+			added:   []string{"#/components/schemas/Page2", "#/components/schemas/Schema1"},
+			schemas: map[string]*Schema{
+				"/owners":  ref("#/components/schemas/Page2", ""),
+				"/pets":    ref("#/components/schemas/Page2", "A page of pets."),
+				"/stores":  ref("#/components/schemas/Page2", "A page of pets."),
+				"filter":   ref("#/components/schemas/Schema1", ""),
+				"/default": ref("#/components/schemas/Error", ""),
+			},
+			page: &inlinePage,
+		},
+		{
+			desc:    "min occurrences",
+			options: DeduplicateOptions{IgnoreDescriptions: true, MinOccurrences: 3},
+			added:   []string{"#/components/schemas/Page2"},
+			schemas: map[string]*Schema{
+				"/owners":  ref("#/components/schemas/Page2", ""),
+				"/pets":    ref("#/components/schemas/Page2", "A page of pets."),
+				"/stores":  ref("#/components/schemas/Page2", "A page of pets."),
+				"filter":   filter,
+				"/default": ref("#/components/schemas/Error", ""),
+			},
+			page: &inlinePage,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			oas, err := FromFile("testdata/deduplicate.yaml")
+			require.NoError(t, err)
+
+			added, err := oas.DeduplicateSchemas(test.options)
+			require.NoError(t, err)
+			assert.Equal(t, test.added, added)
+
+			responseSchema := func(path, status string) *Schema {
+				return (*oas.Paths[path].Get.Responses)[status].Content["application/json"].Schema
+			}
+			for key, expected := range test.schemas {
+				switch key {
+				case "filter":
+					assert.Equal(t, expected, oas.Paths["/owners"].Get.Parameters[0].Schema, key)
+					assert.Equal(t, expected, oas.Paths["/pets"].Get.Parameters[0].Schema, key)
+				case "/default":
+					assert.Equal(t, expected, responseSchema("/pets", "default"), key)
+				default:
+					assert.Equal(t, expected, responseSchema(key, "200"), key)
+				}
+			}
+
+			// The name derived from the title is already used.
+			assert.Equal(t, *test.page, oas.Components.Schemas["Page2"])
+			assert.Equal(t, "cursor", sortedKeys(oas.Components.Schemas["Page"].Properties)[0])
+		})
+	}
+}
+
+func TestOpenAPI_DeduplicateSchemas_nestedDescriptions(t *testing.T) {
+	owner := func(description string) *Schema {
+		return &Schema{JSONSchema: JSONSchema{
+			Type:        "object",
+			Description: "An owner.",
+			Properties:  map[string]JSONSchema{"id": {Type: "string", Description: description}},
+		}}
+	}
+	response := func(schema *Schema) Responses {
+		return Responses{"200": {Description: "OK", Content: map[string]MediaType{"application/json": {Schema: schema}}}}
+	}
+
+	a, b := owner("pet id"), owner("owner id")
+	oas := &OpenAPI{Paths: Paths{
+		"/a": PathItem{Get: &Operation{Responses: &Responses{}}},
+		"/b": PathItem{Get: &Operation{Responses: &Responses{}}},
+	}}
+	*oas.Paths["/a"].Get.Responses = response(a)
+	*oas.Paths["/b"].Get.Responses = response(b)
+
+	added, err := oas.DeduplicateSchemas(DeduplicateOptions{IgnoreDescriptions: true})
+	require.NoError(t, err)
+	assert.Empty(t, added)
+	assert.Equal(t, owner("pet id"), a)
+	assert.Equal(t, owner("owner id"), b)
+}
+
+func TestTitleComponentName(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "", expected: ""},
+		{title: "Pet", expected: "Pet"},
+		{title: "pet owner", expected: "PetOwner"},
+		{title: "Page of pets (v2)", expected: "PageOfPetsV2"},
+		{title: "café-order", expected: "CafOrder"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.title, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, titleComponentName(test.title))
+		})
+	}
+}
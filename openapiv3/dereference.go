@@ -0,0 +1,171 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DereferenceOptions configures OpenAPI.Dereference.
+type DereferenceOptions struct {
+	// FailOnCycle makes Dereference fail on a recursive reference rather than keeping it as is.
+	FailOnCycle bool
+}
+
+// Dereference returns a copy of the document with its local references replaced
+// with a copy of their target, itself dereferenced:
+//   - the summary and the description next to a reference override the ones of the target,
+//     the summary only applying to the examples and the path items, as allowed by OpenAPI 3.1;
+//   - the keywords next to a schema reference override the ones of the referenced schema;
+//   - a reference to a target being inlined, as in a recursive schema, is kept as is at the cycle point,
+//     unless the options require to fail;
+//   - the external and unresolved references are kept as is.
+//
+// The components are kept, dereferenced as well, as they still hold the security schemes
+// and the targets of the references kept.
+func (o *OpenAPI) Dereference(options DereferenceOptions) (*OpenAPI, error) {
+	tree, err := toTree(o)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dereferencer{options: options, tree: tree, refs: map[string]any{}}
+	// Only the Reference Objects and the schema references are dereferenced,
+	// not the $ref keys found in the example values or in the extensions.
+	w := &Walker{
+		Enter: func(node *WalkNode) error {
+			if refOf(node.Value) != "" {
+				d.refs[node.Pointer] = node.Value
+			}
+			return nil
+		},
+	}
+	// The hook does not fail.
+	_ = w.Walk(o)
+
+	dereferenced, err := d.node("", tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc OpenAPI
+	if err = fromTree(dereferenced, &doc); err != nil {
+		return nil, fmt.Errorf("dereferenced document: %w", err)
+	}
+
+	return &doc, nil
+}
+
+type dereferencer struct {
+	options DereferenceOptions
+	tree    any
+	// refs holds the nodes of the document holding a reference, by pointer.
+	refs map[string]any
+	// inlining holds the pointers of the targets being inlined, to detect the reference cycles.
+	inlining []string
+}
+
+// node returns a dereferenced copy of the node of the document at a pointer.
+func (d *dereferencer) node(ptr string, node any) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			if value, isRef := d.refs[ptr]; isRef {
+				return d.reference(ptr, v, ref, value)
+			}
+		}
+
+		c := make(map[string]any, len(v))
+		// The keys are sorted for the error to be deterministic.
+		for _, key := range sortedKeys(v) {
+			dereferenced, err := d.node(appendPointer(ptr, key), v[key])
+			if err != nil {
+				return nil, err
+			}
+			c[key] = dereferenced
+		}
+		return c, nil
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			dereferenced, err := d.node(appendPointer(ptr, fmt.Sprint(i)), child)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = dereferenced
+		}
+		return c, nil
+	default:
+		return node, nil
+	}
+}
+
+// reference returns a dereferenced copy of the target of a reference, with the overrides of the reference applied.
+func (d *dereferencer) reference(ptr string, item map[string]any, ref string, value any) (any, error) {
+	targetPtr := strings.TrimPrefix(ref, "#")
+	for _, inlining := range d.inlining {
+		if inlining == targetPtr {
+			if d.options.FailOnCycle {
+				return nil, fmt.Errorf("%s: recursive reference %q", ptr, ref)
+			}
+			return copyTree(item), nil
+		}
+	}
+
+	target, ok := lookupTree(d.tree, targetPtr)
+	if !ok {
+		return copyTree(item), nil
+	}
+
+	d.inlining = append(d.inlining, targetPtr)
+	defer func() { d.inlining = d.inlining[:len(d.inlining)-1] }()
+
+	inlined, err := d.node(targetPtr, target)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := inlined.(map[string]any)
+	if !ok {
+		return inlined, nil
+	}
+	for _, key := range referenceOverrides(item, value) {
+		if m[key], err = d.node(appendPointer(ptr, key), item[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// referenceOverrides returns the keys of a reference overriding the ones of its target held by a node of the document:
+// the keywords next to a schema reference, and the non empty summary and description of a Reference Object.
+func referenceOverrides(item map[string]any, node any) []string {
+	var keys []string
+	switch node.(type) {
+	case *Schema, *JSONSchema:
+		for _, key := range sortedKeys(item) {
+			if key != "$ref" {
+				keys = append(keys, key)
+			}
+		}
+	default:
+		for _, key := range []string{"description", "summary"} {
+			if value, _ := item[key].(string); value != "" && (key != "summary" || allowsSummary(node)) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// allowsSummary reports whether the target of a reference held by a node of the document has a summary,
+// which the reference can override.
+func allowsSummary(node any) bool {
+	switch node.(type) {
+	case *Parameter, *Header, *Response, *RequestBody, *Link:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,92 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Dereference(t *testing.T) {
+	oas, err := FromFile("testdata/dereference.yaml")
+	require.NoError(t, err)
+
+	dereferenced, err := oas.Dereference(DereferenceOptions{})
+	require.NoError(t, err)
+
+	doc, err := toTree(dereferenced)
+	require.NoError(t, err)
+
+	lookup := func(ptr string) any {
+		t.Helper()
+
+		node, ok := lookupTree(doc, ptr)
+		require.True(t, ok, ptr)
+
+		return node
+	}
+
+	assert.Equal(t, map[string]any{
+		"name":        "limit",
+		"in":          "query",
+		"description": "The maximum number of pets to return.",
+		"schema":      map[string]any{"type": "integer"},
+	}, lookup("/paths/~1pets/get/parameters/0"))
+
+	// The fields of a referenced request body or response are kept, only the description being overridden.
+	assert.Equal(t, map[string]any{
+		"required": true,
+		"content":  map[string]any{"application/json": map[string]any{"schema": map[string]any{"type": "string"}}},
+	}, lookup("/paths/~1pets/post/requestBody"))
+	assert.Equal(t, map[string]any{
+		"description": "The created pet.",
+		"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"type": "string"}}},
+	}, lookup("/paths/~1pets/post/responses/201"))
+	require.NoError(t, dereferenced.Validate())
+
+	// A reference to a reference is followed.
+	assert.Equal(t, "The pets.", lookup("/paths/~1pets/get/responses/200/description"))
+	assert.Equal(t, map[string]any{
+		"summary": "Some pets.",
+		"value":   []any{map[string]any{"name": "Rex", "$ref": "not a reference"}},
+	}, lookup("/paths/~1pets/get/responses/200/content/application~1json/examples/some"))
+
+	// The external references are kept.
+	assert.Equal(t, "other.yaml#/components/responses/Error", lookup("/paths/~1pets/get/responses/default/$ref"))
+
+	// The items, the additional properties and the properties are inlined, the keywords next to a reference override the target ones.
+	pet := "/paths/~1pets/get/responses/200/content/application~1json/schema/items"
+	assert.Equal(t, map[string]any{"type": "string"}, lookup(pet+"/properties/tags/items"))
+	assert.Equal(t, map[string]any{"type": "string"}, lookup(pet+"/properties/attributes/additionalProperties"))
+	assert.Equal(t, "The owner of the pet.", lookup(pet+"/properties/owner/description"))
+
+	// The recursive references are kept at the cycle point.
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Pet"}, lookup(pet+"/properties/owner/properties/pets/items"))
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Pet"}, lookup("/components/schemas/Owner/properties/pets/items/properties/owner/properties/pets/items"))
+
+	assert.Equal(t, "object", lookup("/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/type"))
+	assert.Equal(t, "The pets.", lookup("/components/responses/PetsAlias/description"))
+
+	// The inlined schemas are part of the model.
+	responses := *dereferenced.Paths["/pets"].Get.Responses
+	assert.Equal(t, &JSONSchema{Type: "string"}, responses["200"].Content["application/json"].Schema.Items.Properties["tags"].Items)
+
+	// The document is left untouched.
+	assert.Equal(t, "#/components/parameters/Limit", oas.Paths["/pets"].Get.Parameters[0].Ref)
+}
+
+func TestOpenAPI_Dereference_failOnCycle(t *testing.T) {
+	oas, err := FromFile("testdata/dereference.yaml")
+	require.NoError(t, err)
+
+	_, err = oas.Dereference(DereferenceOptions{FailOnCycle: true})
+	assert.EqualError(t, err, `/components/schemas/Owner/properties/pets/items: recursive reference "#/components/schemas/Pet"`)
+
+	delete(oas.Components.Schemas["Owner"].Properties, "pets")
+	dereferenced, err := oas.Dereference(DereferenceOptions{FailOnCycle: true})
+	require.NoError(t, err)
+	content, err := json.Marshal(dereferenced)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), `"#/components/`)
+}
@@ -0,0 +1,252 @@
+package openapiv3
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ChangeKind is the kind of difference between two nodes of OpenAPI documents.
+type ChangeKind string
+
+// Change kinds.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change describes a difference between two OpenAPI documents.
+type Change struct {
+	// Pointer is the JSON Pointer of the changed node.
+	// It points into the base document for removed and modified nodes, and into the revision for added nodes.
+	Pointer string     `json:"pointer"`
+	Kind    ChangeKind `json:"kind"`
+	// Old is the JSON value of the node in the base document, nil if the node has been added.
+	Old any `json:"old,omitempty"`
+	// New is the JSON value of the node in the revision, nil if the node has been removed.
+	New any `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s", c.Kind, c.Pointer)
+}
+
+// Diff compares the API described by two documents and returns their differences.
+//
+// The comparison covers the servers, the paths (with their operations, parameters, request bodies and responses),
+// the security requirements and the security schemes.
+// Local references are resolved before comparing, so the schemas and the other components are compared where they are used:
+// moving an inline definition into the components, or renaming a component, is not reported as a change.
+// Parameters are matched by location and name, and servers by URL, so reordering them is not a change either.
+func Diff(base, revision *OpenAPI) ([]Change, error) {
+	baseSurface, err := apiSurface(base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+
+	revisionSurface, err := apiSurface(revision)
+	if err != nil {
+		return nil, fmt.Errorf("revision: %w", err)
+	}
+
+	d := &differ{}
+	d.compare("", "", baseSurface, revisionSurface)
+
+	return d.changes, nil
+}
+
+// apiSurface returns the parts of the document describing the API, with the local references resolved.
+func apiSurface(o *OpenAPI) (map[string]any, error) {
+	tree, err := toTree(o)
+	if err != nil {
+		return nil, err
+	}
+	doc, _ := tree.(map[string]any)
+
+	surface := map[string]any{}
+	for _, field := range []string{"servers", "paths", "security"} {
+		if node, ok := doc[field]; ok {
+			surface[field] = resolveTree(doc, node, nil)
+		}
+	}
+
+	if schemes, ok := lookupTree(doc, "/components/securitySchemes"); ok {
+		surface["components"] = map[string]any{"securitySchemes": schemes}
+	}
+
+	return surface, nil
+}
+
+// resolveTree returns a copy of the node where the local references are replaced by their target.
+// The fields defined next to a reference override the ones of the target.
+// A reference leading to a cycle is kept as is.
+func resolveTree(root, node any, resolving []string) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			return resolveRef(root, v, ref, resolving)
+		}
+
+		resolved := make(map[string]any, len(v))
+		for key, value := range v {
+			resolved[key] = resolveTree(root, value, resolving)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, value := range v {
+			resolved[i] = resolveTree(root, value, resolving)
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+func resolveRef(root any, node map[string]any, ref string, resolving []string) any {
+	for _, r := range resolving {
+		if r == ref {
+			return copyTree(node)
+		}
+	}
+
+	target, ok := lookupTree(root, strings.TrimPrefix(ref, "#"))
+	if !ok {
+		return copyTree(node)
+	}
+
+	resolved := resolveTree(root, target, append(resolving, ref))
+	object, ok := resolved.(map[string]any)
+	if !ok {
+		return resolved
+	}
+
+	for key, value := range node {
+		if key != "$ref" {
+			object[key] = resolveTree(root, value, resolving)
+		}
+	}
+
+	return object
+}
+
+type differ struct {
+	changes []Change
+}
+
+// compare records the differences between two nodes.
+// The base and revision pointers only differ for the elements of arrays matched by key.
+func (d *differ) compare(basePtr, revisionPtr string, base, revision any) {
+	switch b := base.(type) {
+	case map[string]any:
+		if r, ok := revision.(map[string]any); ok {
+			d.compareObjects(basePtr, revisionPtr, b, r)
+			return
+		}
+	case []any:
+		if r, ok := revision.([]any); ok && d.compareKeyedArrays(basePtr, revisionPtr, b, r) {
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(base, revision) {
+		d.changes = append(d.changes, Change{Pointer: basePtr, Kind: ChangeModified, Old: base, New: revision})
+	}
+}
+
+func (d *differ) compareObjects(basePtr, revisionPtr string, base, revision map[string]any) {
+	keys := sortedKeys(base)
+	for _, key := range sortedKeys(revision) {
+		if _, ok := base[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		b, inBase := base[key]
+		r, inRevision := revision[key]
+
+		switch {
+		case !inRevision:
+			d.changes = append(d.changes, Change{Pointer: appendPointer(basePtr, key), Kind: ChangeRemoved, Old: b})
+		case !inBase:
+			d.changes = append(d.changes, Change{Pointer: appendPointer(revisionPtr, key), Kind: ChangeAdded, New: r})
+		default:
+			d.compare(appendPointer(basePtr, key), appendPointer(revisionPtr, key), b, r)
+		}
+	}
+}
+
+// compareKeyedArrays compares arrays whose elements are identified by a key rather than by their position,
+// and reports whether the arrays are keyed.
+func (d *differ) compareKeyedArrays(basePtr, revisionPtr string, base, revision []any) bool {
+	baseIndexes, ok := keyIndexes(base)
+	if !ok {
+		return false
+	}
+	revisionIndexes, ok := keyIndexes(revision)
+	if !ok {
+		return false
+	}
+
+	for _, b := range base {
+		key, _ := elementKey(b)
+		i := baseIndexes[key]
+
+		j, ok := revisionIndexes[key]
+		if !ok {
+			d.changes = append(d.changes, Change{Pointer: appendPointer(basePtr, fmt.Sprint(i)), Kind: ChangeRemoved, Old: b})
+			continue
+		}
+
+		d.compare(appendPointer(basePtr, fmt.Sprint(i)), appendPointer(revisionPtr, fmt.Sprint(j)), b, revision[j])
+	}
+
+	for j, r := range revision {
+		key, _ := elementKey(r)
+		if _, ok := baseIndexes[key]; !ok {
+			d.changes = append(d.changes, Change{Pointer: appendPointer(revisionPtr, fmt.Sprint(j)), Kind: ChangeAdded, New: r})
+		}
+	}
+
+	return true
+}
+
+// keyIndexes returns the position of each element of an array by key,
+// or false if the elements cannot be identified by a unique key.
+func keyIndexes(elements []any) (map[string]int, bool) {
+	indexes := make(map[string]int, len(elements))
+	for i, element := range elements {
+		key, ok := elementKey(element)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := indexes[key]; ok {
+			return nil, false
+		}
+		indexes[key] = i
+	}
+
+	return indexes, true
+}
+
+// elementKey returns the key identifying a parameter (location and name) or a server (URL).
+func elementKey(element any) (string, bool) {
+	object, ok := element.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	name, hasName := object["name"].(string)
+	in, hasIn := object["in"].(string)
+	if hasName && hasIn {
+		return "parameter:" + in + ":" + name, true
+	}
+
+	if url, ok := object["url"].(string); ok {
+		return "server:" + url, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,115 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		desc     string
+		update   func(oas *OpenAPI)
+		expected []Change
+	}{
+		{
+			desc:   "same document",
+			update: func(oas *OpenAPI) {},
+		},
+		{
+			desc: "removed operation",
+			update: func(oas *OpenAPI) {
+				pathItem := oas.Paths["/pets"]
+				pathItem.Post = nil
+				oas.Paths["/pets"] = pathItem
+			},
+			expected: []Change{
+				{Pointer: "/paths/~1pets/post", Kind: ChangeRemoved, Old: map[string]any{
+					"summary":     "Create a pet",
+					"operationId": "createPets",
+					"tags":        []any{"pets"},
+					"responses": map[string]any{
+						"201": map[string]any{"description": "Null response"},
+						"default": map[string]any{
+							"description": "unexpected error",
+							"content": map[string]any{
+								"application/json": map[string]any{"schema": map[string]any{
+									"type":     "object",
+									"required": []any{"code", "message"},
+									"properties": map[string]any{
+										"code":    map[string]any{"type": "integer", "format": "int32"},
+										"message": map[string]any{"type": "string"},
+									},
+								}},
+							},
+						},
+					},
+				}},
+			},
+		},
+		{
+			desc: "added parameter",
+			update: func(oas *OpenAPI) {
+				oas.Paths["/pets"].Get.Parameters = append([]Parameter{
+					{Name: "name", In: "query", Schema: &Schema{JSONSchema: JSONSchema{Type: "string"}}},
+				}, oas.Paths["/pets"].Get.Parameters...)
+			},
+			expected: []Change{
+				{Pointer: "/paths/~1pets/get/parameters/0", Kind: ChangeAdded, New: map[string]any{
+					"name":   "name",
+					"in":     "query",
+					"schema": map[string]any{"type": "string"},
+				}},
+			},
+		},
+		{
+			desc: "changed referenced schema",
+			update: func(oas *OpenAPI) {
+				oas.Components.Schemas["Pet"].Properties["id"] = JSONSchema{Type: "string"}
+			},
+			expected: []Change{
+				{Pointer: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/id/format", Kind: ChangeRemoved, Old: "int64"},
+				{Pointer: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/id/type", Kind: ChangeModified, Old: "integer", New: "string"},
+				{Pointer: "/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/properties/id/format", Kind: ChangeRemoved, Old: "int64"},
+				{Pointer: "/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/properties/id/type", Kind: ChangeModified, Old: "integer", New: "string"},
+			},
+		},
+		{
+			desc: "inline schema moved into components",
+			update: func(oas *OpenAPI) {
+				oas.Components.Schemas["Limit"] = *oas.Paths["/pets"].Get.Parameters[0].Schema
+				oas.Paths["/pets"].Get.Parameters[0].Schema = &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Limit"}}
+			},
+		},
+		{
+			desc: "changed server",
+			update: func(oas *OpenAPI) {
+				oas.Servers = []Server{{URL: "https://petstore.swagger.io/v2"}}
+			},
+			expected: []Change{
+				{Pointer: "/servers/0", Kind: ChangeRemoved, Old: map[string]any{"url": "http://petstore.swagger.io/v1"}},
+				{Pointer: "/servers/0", Kind: ChangeAdded, New: map[string]any{"url": "https://petstore.swagger.io/v2"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			base, err := FromFile("testdata/petstore.yaml")
+			require.NoError(t, err)
+
+			revision, err := FromFile("testdata/petstore.yaml")
+			require.NoError(t, err)
+			test.update(revision)
+
+			changes, err := Diff(base, revision)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package openapiv3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// CallbackRequest is an outbound callback request of an operation, computed from the incoming request.
+type CallbackRequest struct {
+	// Name is the name of the callback in the operation.
+	Name string
+	// Expression is the callback key, e.g. {$request.body#/callbackUrl}.
+	Expression string
+	// URL is the evaluated callback key.
+	URL string
+	// PathItem describes the requests which can be sent to the URL.
+	PathItem PathItem
+}
+
+// EvaluateCallbacks evaluates the callback keys of an operation against the exchange of the incoming request,
+// to compute the URLs of the outbound callback requests.
+func (o *OpenAPI) EvaluateCallbacks(op *Operation, exchange *Exchange) ([]CallbackRequest, error) {
+	var requests []CallbackRequest
+	for _, name := range sortedKeys(op.Callbacks) {
+		callback := op.Callbacks[name]
+		for _, expression := range sortedKeys(callback) {
+			value, err := evaluateExpressionValue(expression, exchange)
+			if err != nil {
+				return nil, fmt.Errorf("callback %q: %w", name, err)
+			}
+
+			u, err := formatValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("callback %q: %w", name, err)
+			}
+
+			pathItem, err := o.ResolvePathItem(callback[expression])
+			if err != nil {
+				return nil, fmt.Errorf("callback %q: %w", name, err)
+			}
+
+			requests = append(requests, CallbackRequest{Name: name, Expression: expression, URL: u, PathItem: pathItem})
+		}
+	}
+
+	return requests, nil
+}
+
+// NewCallbackRequest returns the HTTP request to send a callback, once its payload validated, see NewOutboundRequest.
+func (o *OpenAPI) NewCallbackRequest(ctx context.Context, cr CallbackRequest, method string, payload any) (*http.Request, error) {
+	req, err := o.NewOutboundRequest(ctx, cr.PathItem, method, cr.URL, payload)
+	if err != nil {
+		return nil, fmt.Errorf("callback %q: %w", cr.Name, err)
+	}
+
+	return req, nil
+}
+
+// NewWebhookRequest returns the HTTP request to send a webhook to the given URL, once its payload validated,
+// see NewOutboundRequest.
+func (o *OpenAPI) NewWebhookRequest(ctx context.Context, name, method, targetURL string, payload any) (*http.Request, error) {
+	pathItem, err := o.webhook(name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.NewOutboundRequest(ctx, pathItem, method, targetURL, payload)
+	if err != nil {
+		return nil, fmt.Errorf("webhook %q: %w", name, err)
+	}
+
+	return req, nil
+}
+
+// ValidateWebhookPayload validates the payload of a webhook request against the request body of the webhook operation.
+func (o *OpenAPI) ValidateWebhookPayload(name, method, contentType string, payload []byte) error {
+	pathItem, err := o.webhook(name)
+	if err != nil {
+		return err
+	}
+
+	op := pathItem.GetOperation(method)
+	if op == nil {
+		return fmt.Errorf("webhook %q: unknown operation %s", name, method)
+	}
+
+	if err = o.ValidateRequestBody(op, contentType, payload); err != nil {
+		return fmt.Errorf("webhook %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func (o *OpenAPI) webhook(name string) (PathItem, error) {
+	pathItem, ok := o.Webhooks[name]
+	if !ok {
+		return PathItem{}, fmt.Errorf("unknown webhook %q", name)
+	}
+
+	pathItem, err := o.ResolvePathItem(pathItem)
+	if err != nil {
+		return PathItem{}, fmt.Errorf("webhook %q: %w", name, err)
+	}
+
+	return pathItem, nil
+}
+
+// NewOutboundRequest returns an HTTP request of an operation of a Path Item describing outbound requests,
+// as the callbacks and the webhooks.
+// The payload is encoded as JSON, unless it is already a []byte, then validated against the request body schema
+// of the JSON media type of the operation.
+func (o *OpenAPI) NewOutboundRequest(ctx context.Context, pathItem PathItem, method, targetURL string, payload any) (*http.Request, error) {
+	op := pathItem.GetOperation(method)
+	if op == nil {
+		return nil, fmt.Errorf("unknown operation %s", method)
+	}
+
+	var (
+		body        io.Reader
+		contentType string
+	)
+	if payload != nil {
+		raw, ok := payload.([]byte)
+		if !ok {
+			var err error
+			if raw, err = json.Marshal(payload); err != nil {
+				return nil, fmt.Errorf("marshal payload: %w", err)
+			}
+		}
+
+		var err error
+		if contentType, err = o.jsonContentType(op); err != nil {
+			return nil, err
+		}
+		if err = o.ValidateRequestBody(op, contentType, raw); err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(raw)
+	} else if err := o.ValidateRequestBody(op, "", nil); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), targetURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return req, nil
+}
+
+// jsonContentType returns the JSON media type of the request body of an operation: application/json if defined,
+// otherwise the first JSON one, or application/json when the operation defines no request body.
+func (o *OpenAPI) jsonContentType(op *Operation) (string, error) {
+	if op.RequestBody == nil {
+		return "application/json", nil
+	}
+
+	rb, err := o.ResolveRequestBody(*op.RequestBody)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := rb.Content["application/json"]; ok {
+		return "application/json", nil
+	}
+	for _, mediaType := range sortedKeys(rb.Content) {
+		if isJSONMediaType(mediaType) {
+			return mediaType, nil
+		}
+	}
+
+	return "", fmt.Errorf("no JSON media type in %s", strings.Join(sortedKeys(rb.Content), ", "))
+}
+
+// ValidateRequestBody validates a request body against the request body of an operation.
+// The body is required if the request body is, and its content type must match one of the media types.
+// The JSON bodies are validated against the schema of their media type, the other ones are not.
+func (o *OpenAPI) ValidateRequestBody(op *Operation, contentType string, body []byte) error {
+	if op.RequestBody == nil {
+		return nil
+	}
+
+	rb, err := o.ResolveRequestBody(*op.RequestBody)
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 {
+		if rb.Required {
+			return fmt.Errorf("missing required request body")
+		}
+		return nil
+	}
+
+	mediaType, ok := matchMediaType(rb.Content, contentType)
+	if !ok {
+		return fmt.Errorf("unsupported content type %q", contentType)
+	}
+
+	if mediaType.Schema == nil || !isJSONMediaType(contentType) {
+		return nil
+	}
+
+	return o.ValidatePayload(mediaType.Schema.JSONSchema, body)
+}
+
+// matchMediaType returns the media type of a content matching a content type:
+// the exact one first, then the type range (e.g. application/*), then */*.
+func matchMediaType(content map[string]MediaType, contentType string) (MediaType, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return MediaType{}, false
+	}
+
+	typ, _, _ := strings.Cut(mediaType, "/")
+	for _, candidate := range []string{mediaType, typ + "/*", "*/*"} {
+		for key, mt := range content {
+			if strings.EqualFold(key, candidate) {
+				return mt, true
+			}
+		}
+	}
+
+	return MediaType{}, false
+}
+
+// isJSONMediaType reports whether a media type is JSON, such as application/json or application/problem+json.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,166 @@
+package openapiv3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dispatchOAS() *OpenAPI {
+	eventBody := &RequestBody{
+		Required: true,
+		Content: map[string]MediaType{
+			"application/json": {Schema: &Schema{JSONSchema: JSONSchema{
+				Type:       "object",
+				Required:   []string{"event"},
+				Properties: map[string]JSONSchema{"event": {Type: "string", Enum: []any{"created", "deleted"}}},
+			}}},
+		},
+	}
+
+	return &OpenAPI{
+		Paths: Paths{
+			"/subscriptions": PathItem{
+				Post: &Operation{
+					Callbacks: map[string]Callback{
+						"onEvent": {
+							"{$request.body#/callbackUrl}?id={$response.body#/id}": PathItem{
+								Post: &Operation{RequestBody: &RequestBody{Reference: Reference{Ref: "#/components/requestBodies/event"}}},
+							},
+						},
+						"onCancel": {
+							"$request.query.cancelUrl": PathItem{Delete: &Operation{}},
+						},
+					},
+				},
+			},
+		},
+		Webhooks: map[string]PathItem{
+			"petEvent": {Post: &Operation{RequestBody: eventBody}},
+			"ping":     {Reference: Reference{Ref: "#/components/pathItems/ping"}},
+		},
+		Components: &Components{
+			RequestBodies: map[string]RequestBody{"event": *eventBody},
+			PathItems: map[string]PathItem{
+				"ping": {Post: &Operation{RequestBody: &RequestBody{Content: map[string]MediaType{"text/plain": {}}}}},
+			},
+		},
+	}
+}
+
+func TestOpenAPI_EvaluateCallbacks(t *testing.T) {
+	oas := dispatchOAS()
+
+	exchange := &Exchange{
+		Request:      httptest.NewRequest(http.MethodPost, "http://example.com/subscriptions?cancelUrl=https://client.example.com/cancel", nil),
+		RequestBody:  []byte(`{"callbackUrl":"https://client.example.com/events"}`),
+		Response:     &http.Response{StatusCode: http.StatusCreated},
+		ResponseBody: []byte(`{"id":12}`),
+	}
+
+	callbacks, err := oas.EvaluateCallbacks(oas.Paths["/subscriptions"].Post, exchange)
+	require.NoError(t, err)
+	require.Len(t, callbacks, 2)
+
+	assert.Equal(t, "onCancel", callbacks[0].Name)
+	assert.Equal(t, "https://client.example.com/cancel", callbacks[0].URL)
+	assert.NotNil(t, callbacks[0].PathItem.Delete)
+
+	assert.Equal(t, "onEvent", callbacks[1].Name)
+	assert.Equal(t, "{$request.body#/callbackUrl}?id={$response.body#/id}", callbacks[1].Expression)
+	assert.Equal(t, "https://client.example.com/events?id=12", callbacks[1].URL)
+
+	req, err := oas.NewCallbackRequest(context.Background(), callbacks[1], http.MethodPost, map[string]any{"event": "created"})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "https://client.example.com/events?id=12", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"event":"created"}`, string(body))
+
+	_, err = oas.NewCallbackRequest(context.Background(), callbacks[1], http.MethodPost, map[string]any{"event": "updated"})
+	assert.EqualError(t, err, `callback "onEvent": /event: value updated is not one of [created deleted]`)
+
+	_, err = oas.NewCallbackRequest(context.Background(), callbacks[1], http.MethodPost, nil)
+	assert.EqualError(t, err, `callback "onEvent": missing required request body`)
+
+	exchange.RequestBody = []byte(`{}`)
+	_, err = oas.EvaluateCallbacks(oas.Paths["/subscriptions"].Post, exchange)
+	assert.EqualError(t, err, `callback "onEvent": evaluate $request.body#/callbackUrl: undefined value`)
+}
+
+func TestOpenAPI_ValidateWebhookPayload(t *testing.T) {
+	oas := dispatchOAS()
+
+	tests := []struct {
+		desc        string
+		name        string
+		method      string
+		contentType string
+		payload     string
+		expected    string
+	}{
+		{desc: "valid", name: "petEvent", method: "POST", contentType: "application/json", payload: `{"event":"deleted"}`},
+		{desc: "content type parameters", name: "petEvent", method: "post", contentType: "application/json; charset=utf-8", payload: `{"event":"deleted"}`},
+		{desc: "not validated media type", name: "ping", method: "POST", contentType: "text/plain", payload: "ping"},
+		{
+			desc:        "invalid payload",
+			name:        "petEvent",
+			method:      "POST",
+			contentType: "application/json",
+			payload:     `{}`,
+			expected:    `webhook "petEvent": missing required property "event"`,
+		},
+		{
+			desc:        "malformed payload",
+			name:        "petEvent",
+			method:      "POST",
+			contentType: "application/json",
+			payload:     `{`,
+			expected:    `webhook "petEvent": decode payload: unexpected end of JSON input`,
+		},
+		{
+			desc:        "unsupported content type",
+			name:        "petEvent",
+			method:      "POST",
+			contentType: "text/plain",
+			payload:     "created",
+			expected:    `webhook "petEvent": unsupported content type "text/plain"`,
+		},
+		{desc: "unknown operation", name: "petEvent", method: "PUT", expected: `webhook "petEvent": unknown operation PUT`},
+		{desc: "unknown webhook", name: "unknown", method: "POST", expected: `unknown webhook "unknown"`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := oas.ValidateWebhookPayload(test.name, test.method, test.contentType, []byte(test.payload))
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestOpenAPI_NewWebhookRequest(t *testing.T) {
+	oas := dispatchOAS()
+
+	req, err := oas.NewWebhookRequest(context.Background(), "petEvent", http.MethodPost, "https://client.example.com/hooks", []byte(`{"event":"created"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "https://client.example.com/hooks", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	_, err = oas.NewWebhookRequest(context.Background(), "ping", http.MethodPost, "https://client.example.com/hooks", "ping")
+	assert.EqualError(t, err, `webhook "ping": no JSON media type in text/plain`)
+}
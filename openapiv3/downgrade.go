@@ -0,0 +1,443 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DowngradedVersion is the OpenAPI version of the documents produced by Downgrade.
+const DowngradedVersion = "3.0.3"
+
+const componentsPathItemsPrefix = "#/components/pathItems/"
+
+// pathItemMethods lists the operation fields of a Path Item Object in their specification order.
+var pathItemMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// DowngradeIssue describes a part of the document that has been dropped or altered
+// because it cannot be represented in OpenAPI 3.0.
+type DowngradeIssue struct {
+	// Pointer is the JSON Pointer of the node in the original document.
+	Pointer string
+	// Message explains what could not be represented.
+	Message string
+}
+
+func (i DowngradeIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Pointer, i.Message)
+}
+
+// Downgrade exports the document as an OpenAPI 3.0.3 JSON document.
+//
+// Where possible, the 3.1 constructs are rewritten into their 3.0 equivalent:
+//   - type arrays containing "null" become a single type with "nullable",
+//   - numeric exclusiveMinimum and exclusiveMaximum become boolean flags on minimum and maximum,
+//   - references to components/pathItems are inlined.
+//
+// Everything else that has no 3.0 equivalent (webhooks, license identifier, info summary, etc.) is removed
+// and reported as a DowngradeIssue.
+func (o *OpenAPI) Downgrade() ([]byte, []DowngradeIssue, error) {
+	tree, err := toTree(o)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, _ := tree.(map[string]any)
+
+	d := &downgrader{referenced: map[string]bool{}}
+	d.document(doc)
+
+	content, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal downgraded document: %w", err)
+	}
+
+	return content, d.issues, nil
+}
+
+type downgrader struct {
+	pathItems map[string]any
+	// referenced holds the names of the path items referenced by the document.
+	referenced map[string]bool
+	// inlining holds the names of the path items being inlined, to detect reference cycles.
+	inlining []string
+	issues   []DowngradeIssue
+}
+
+func (d *downgrader) report(ptr, format string, args ...any) {
+	d.issues = append(d.issues, DowngradeIssue{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *downgrader) document(doc map[string]any) {
+	doc["openapi"] = DowngradedVersion
+
+	if _, ok := doc["jsonSchemaDialect"]; ok {
+		delete(doc, "jsonSchemaDialect")
+		d.report("/jsonSchemaDialect", "jsonSchemaDialect is not supported, removed")
+	}
+
+	if info, ok := doc["info"].(map[string]any); ok {
+		if _, ok := info["summary"]; ok {
+			delete(info, "summary")
+			d.report("/info/summary", "info summary is not supported, removed")
+		}
+		if license, ok := info["license"].(map[string]any); ok {
+			if _, ok := license["identifier"]; ok {
+				delete(license, "identifier")
+				d.report("/info/license/identifier", "license identifier is not supported, removed")
+			}
+		}
+	}
+
+	if webhooks, ok := doc["webhooks"].(map[string]any); ok {
+		delete(doc, "webhooks")
+		for _, name := range sortedKeys(webhooks) {
+			d.report(appendPointer("/webhooks", name), "webhooks are not supported, removed")
+		}
+	}
+
+	if components, ok := doc["components"].(map[string]any); ok {
+		// Path items are inlined where they are referenced, so they must be known before walking the paths.
+		d.pathItems, _ = components["pathItems"].(map[string]any)
+		delete(components, "pathItems")
+	}
+
+	paths, ok := doc["paths"].(map[string]any)
+	if !ok {
+		// The paths field is required in OpenAPI 3.0.
+		paths = map[string]any{}
+		doc["paths"] = paths
+	}
+	for _, path := range sortedKeys(paths) {
+		paths[path] = d.pathItem(appendPointer("/paths", path), paths[path])
+	}
+
+	if components, ok := doc["components"].(map[string]any); ok {
+		d.components(components)
+		if len(components) == 0 {
+			delete(doc, "components")
+		}
+	}
+
+	for _, name := range sortedKeys(d.pathItems) {
+		if !d.referenced[name] {
+			d.report(appendPointer("/components/pathItems", name), "unreferenced path items are not supported, removed")
+		}
+	}
+}
+
+func (d *downgrader) components(components map[string]any) {
+	each := func(field string, fn func(ptr string, node map[string]any)) {
+		nodes, _ := components[field].(map[string]any)
+		for _, name := range sortedKeys(nodes) {
+			if node, ok := nodes[name].(map[string]any); ok {
+				fn(appendPointer("/components", field, name), node)
+			}
+		}
+	}
+
+	each("schemas", d.schema)
+	each("responses", d.response)
+	each("parameters", d.parameter)
+	each("examples", d.example)
+	each("requestBodies", d.requestBody)
+	each("headers", d.parameter)
+	each("links", func(ptr string, node map[string]any) { d.reference(ptr, node) })
+	each("callbacks", d.callback)
+
+	if schemes, ok := components["securitySchemes"].(map[string]any); ok {
+		for _, name := range sortedKeys(schemes) {
+			scheme, _ := schemes[name].(map[string]any)
+			if scheme["type"] == "mutualTLS" {
+				delete(schemes, name)
+				d.report(appendPointer("/components/securitySchemes", name), "mutualTLS security scheme is not supported, removed")
+			}
+		}
+		if len(schemes) == 0 {
+			delete(components, "securitySchemes")
+		}
+	}
+}
+
+// pathItem downgrades a path item and returns it, inlined if it references a components path item.
+func (d *downgrader) pathItem(ptr string, node any) any {
+	item, ok := node.(map[string]any)
+	if !ok {
+		return node
+	}
+
+	if ref, ok := item["$ref"].(string); ok && strings.HasPrefix(ref, componentsPathItemsPrefix) {
+		name := strings.TrimPrefix(ref, componentsPathItemsPrefix)
+		d.referenced[name] = true
+		for _, inlining := range d.inlining {
+			if inlining == name {
+				d.report(ptr, "recursive reference %q cannot be inlined, kept as is", ref)
+				return item
+			}
+		}
+
+		target, ok := d.pathItems[name]
+		if !ok {
+			d.report(ptr, "unresolved reference %q, kept as is", ref)
+			return item
+		}
+
+		inlined, _ := copyTree(target).(map[string]any)
+		// Fields defined next to the reference override the referenced ones.
+		for key, value := range item {
+			if key != "$ref" {
+				inlined[key] = value
+			}
+		}
+
+		d.inlining = append(d.inlining, name)
+		defer func() { d.inlining = d.inlining[:len(d.inlining)-1] }()
+
+		return d.pathItem(ptr, inlined)
+	}
+
+	for _, method := range pathItemMethods {
+		if op, ok := item[method].(map[string]any); ok {
+			d.operation(appendPointer(ptr, method), op)
+		}
+	}
+	d.parameters(appendPointer(ptr, "parameters"), item["parameters"])
+
+	return item
+}
+
+func (d *downgrader) operation(ptr string, op map[string]any) {
+	d.parameters(appendPointer(ptr, "parameters"), op["parameters"])
+
+	if body, ok := op["requestBody"].(map[string]any); ok {
+		d.requestBody(appendPointer(ptr, "requestBody"), body)
+	}
+
+	responses, _ := op["responses"].(map[string]any)
+	for _, code := range sortedKeys(responses) {
+		if response, ok := responses[code].(map[string]any); ok {
+			d.response(appendPointer(ptr, "responses", code), response)
+		}
+	}
+
+	callbacks, _ := op["callbacks"].(map[string]any)
+	for _, name := range sortedKeys(callbacks) {
+		if callback, ok := callbacks[name].(map[string]any); ok {
+			d.callback(appendPointer(ptr, "callbacks", name), callback)
+		}
+	}
+}
+
+func (d *downgrader) callback(ptr string, callback map[string]any) {
+	for _, expression := range sortedKeys(callback) {
+		callback[expression] = d.pathItem(appendPointer(ptr, expression), callback[expression])
+	}
+}
+
+func (d *downgrader) parameters(ptr string, node any) {
+	parameters, _ := node.([]any)
+	for i, parameter := range parameters {
+		if parameter, ok := parameter.(map[string]any); ok {
+			d.parameter(appendPointer(ptr, fmt.Sprint(i)), parameter)
+		}
+	}
+}
+
+// parameter downgrades a parameter or a header, as both share the same structure.
+func (d *downgrader) parameter(ptr string, parameter map[string]any) {
+	if d.reference(ptr, parameter) {
+		return
+	}
+
+	if schema, ok := parameter["schema"].(map[string]any); ok {
+		d.schema(appendPointer(ptr, "schema"), schema)
+	}
+	d.content(appendPointer(ptr, "content"), parameter["content"])
+	d.examples(appendPointer(ptr, "examples"), parameter["examples"])
+}
+
+func (d *downgrader) requestBody(ptr string, body map[string]any) {
+	if d.reference(ptr, body) {
+		return
+	}
+
+	d.content(appendPointer(ptr, "content"), body["content"])
+}
+
+func (d *downgrader) response(ptr string, response map[string]any) {
+	if d.reference(ptr, response) {
+		return
+	}
+
+	headers, _ := response["headers"].(map[string]any)
+	for _, name := range sortedKeys(headers) {
+		if header, ok := headers[name].(map[string]any); ok {
+			d.parameter(appendPointer(ptr, "headers", name), header)
+		}
+	}
+
+	d.content(appendPointer(ptr, "content"), response["content"])
+
+	links, _ := response["links"].(map[string]any)
+	for _, name := range sortedKeys(links) {
+		if link, ok := links[name].(map[string]any); ok {
+			d.reference(appendPointer(ptr, "links", name), link)
+		}
+	}
+}
+
+func (d *downgrader) content(ptr string, node any) {
+	content, _ := node.(map[string]any)
+	for _, mediaType := range sortedKeys(content) {
+		media, ok := content[mediaType].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		mediaPtr := appendPointer(ptr, mediaType)
+		if schema, ok := media["schema"].(map[string]any); ok {
+			d.schema(appendPointer(mediaPtr, "schema"), schema)
+		}
+		d.examples(appendPointer(mediaPtr, "examples"), media["examples"])
+
+		encodings, _ := media["encoding"].(map[string]any)
+		for _, property := range sortedKeys(encodings) {
+			encoding, _ := encodings[property].(map[string]any)
+			headers, _ := encoding["headers"].(map[string]any)
+			for _, name := range sortedKeys(headers) {
+				if header, ok := headers[name].(map[string]any); ok {
+					d.parameter(appendPointer(mediaPtr, "encoding", property, "headers", name), header)
+				}
+			}
+		}
+	}
+}
+
+func (d *downgrader) examples(ptr string, node any) {
+	examples, _ := node.(map[string]any)
+	for _, name := range sortedKeys(examples) {
+		if example, ok := examples[name].(map[string]any); ok {
+			d.example(appendPointer(ptr, name), example)
+		}
+	}
+}
+
+func (d *downgrader) example(ptr string, example map[string]any) {
+	d.reference(ptr, example)
+}
+
+// reference reports whether the node is a Reference Object.
+// In OpenAPI 3.0, a Reference Object cannot override the summary and the description of its target,
+// so these fields are removed, and reported unless empty.
+func (d *downgrader) reference(ptr string, node map[string]any) bool {
+	if _, ok := node["$ref"]; !ok {
+		return false
+	}
+
+	for _, field := range []string{"summary", "description"} {
+		value, ok := node[field]
+		if !ok {
+			continue
+		}
+		delete(node, field)
+		if value != nil && value != "" {
+			d.report(appendPointer(ptr, field), "reference %s override is not supported, removed", field)
+		}
+	}
+
+	return true
+}
+
+func (d *downgrader) schema(ptr string, schema map[string]any) {
+	if ref, ok := schema["$ref"]; ok && len(schema) > 1 {
+		// In OpenAPI 3.0, the keywords next to a $ref are ignored: the reference is moved into an allOf to keep them.
+		delete(schema, "$ref")
+		allOf, _ := schema["allOf"].([]any)
+		schema["allOf"] = append(allOf, map[string]any{"$ref": ref})
+	}
+
+	if types, ok := schema["type"].([]any); ok {
+		d.schemaTypes(ptr, schema, types)
+	}
+
+	d.exclusiveBound(schema, "exclusiveMinimum", "minimum", func(bound, exclusive float64) bool { return exclusive >= bound })
+	d.exclusiveBound(schema, "exclusiveMaximum", "maximum", func(bound, exclusive float64) bool { return exclusive <= bound })
+
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedKeys(properties) {
+		if property, ok := properties[name].(map[string]any); ok {
+			d.schema(appendPointer(ptr, "properties", name), property)
+		}
+	}
+
+	if items, ok := schema["items"].(map[string]any); ok {
+		d.schema(appendPointer(ptr, "items"), items)
+	}
+
+	for _, keyword := range []string{"not", "additionalProperties"} {
+		if subschema, ok := schema[keyword].(map[string]any); ok {
+			d.schema(appendPointer(ptr, keyword), subschema)
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, _ := schema[keyword].([]any)
+		for i, subschema := range subschemas {
+			if subschema, ok := subschema.(map[string]any); ok {
+				d.schema(appendPointer(ptr, keyword, strconv.Itoa(i)), subschema)
+			}
+		}
+	}
+}
+
+// schemaTypes rewrites a type array into a single type, an anyOf of types and the nullable flag.
+func (d *downgrader) schemaTypes(ptr string, schema map[string]any, types []any) {
+	delete(schema, "type")
+
+	var names []string
+	for _, t := range types {
+		name, _ := t.(string)
+		if name == "null" {
+			schema["nullable"] = true
+			continue
+		}
+		names = append(names, name)
+	}
+
+	switch len(names) {
+	case 0:
+		d.report(appendPointer(ptr, "type"), "null type is not supported, replaced by nullable")
+	case 1:
+		schema["type"] = names[0]
+	default:
+		anyOf := make([]any, 0, len(names))
+		for _, name := range names {
+			anyOf = append(anyOf, map[string]any{"type": name})
+		}
+		if _, ok := schema["anyOf"]; ok {
+			// The anyOf of the schema is kept: both have to be satisfied.
+			allOf, _ := schema["allOf"].([]any)
+			schema["allOf"] = append(allOf, map[string]any{"anyOf": anyOf})
+			return
+		}
+		schema["anyOf"] = anyOf
+	}
+}
+
+// exclusiveBound rewrites a numeric exclusive bound into a boolean flag on its inclusive bound.
+// stricter reports whether the exclusive bound restricts more than the inclusive one.
+func (d *downgrader) exclusiveBound(schema map[string]any, exclusiveKey, boundKey string, stricter func(bound, exclusive float64) bool) {
+	exclusive, ok := schema[exclusiveKey].(float64)
+	if !ok {
+		return
+	}
+
+	bound, hasBound := schema[boundKey].(float64)
+	if hasBound && !stricter(bound, exclusive) {
+		delete(schema, exclusiveKey)
+		return
+	}
+
+	schema[boundKey] = exclusive
+	schema[exclusiveKey] = true
+}
@@ -0,0 +1,239 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Downgrade(t *testing.T) {
+	tests := []struct {
+		desc           string
+		json           string
+		expected       string
+		expectedIssues []DowngradeIssue
+	}{
+		{
+			desc: "minimal document",
+			json: `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0.0"}
+}`,
+			expected: `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {}
+}`,
+		},
+		{
+			desc: "unsupported fields",
+			json: `{
+  "openapi": "3.1.0",
+  "jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+  "info": {
+    "title": "Pets",
+    "summary": "Pets API",
+    "version": "1.0.0",
+    "license": {"name": "Apache 2.0", "identifier": "Apache-2.0"}
+  },
+  "webhooks": {
+    "newPet": {"post": {"responses": {"200": {"description": "ok"}}}}
+  },
+  "components": {
+    "securitySchemes": {
+      "mtls": {"type": "mutualTLS"}
+    }
+  }
+}`,
+			expected: `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0", "license": {"name": "Apache 2.0"}},
+  "paths": {}
+}`,
+			expectedIssues: []DowngradeIssue{
+				{Pointer: "/jsonSchemaDialect", Message: "jsonSchemaDialect is not supported, removed"},
+				{Pointer: "/info/summary", Message: "info summary is not supported, removed"},
+				{Pointer: "/info/license/identifier", Message: "license identifier is not supported, removed"},
+				{Pointer: "/webhooks/newPet", Message: "webhooks are not supported, removed"},
+				{Pointer: "/components/securitySchemes/mtls", Message: "mutualTLS security scheme is not supported, removed"},
+			},
+		},
+		{
+			desc: "schemas",
+			json: `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "name": {"type": ["string", "null"]},
+          "age": {"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 30},
+          "weight": {"type": "number", "minimum": 1, "exclusiveMinimum": 0},
+          "tag": {"type": ["string", "integer"]},
+          "nothing": {"type": ["null"]},
+          "owner": {"$ref": "#/components/schemas/Owner", "maxItems": 1},
+          "id": {"type": ["string", "integer"], "anyOf": [{"minLength": 1}, {"minimum": 1}]},
+          "alias": {"oneOf": [{"type": ["string", "null"]}, {"not": {"type": ["integer", "null"]}}]},
+          "rank": {"type": "integer", "maximum": 0, "exclusiveMaximum": 5},
+          "tags": {"type": "array", "items": {"type": ["string", "null"]}},
+          "toys": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": ["string", "null"]}}}},
+          "labels": {"type": "object", "additionalProperties": {"type": ["string", "null"]}}
+        }
+      }
+    }
+  }
+}`,
+			expected: `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string", "nullable": true},
+          "age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 30, "exclusiveMaximum": true},
+          "weight": {"type": "number", "minimum": 1},
+          "tag": {"anyOf": [{"type": "string"}, {"type": "integer"}]},
+          "nothing": {"nullable": true},
+          "owner": {"allOf": [{"$ref": "#/components/schemas/Owner"}], "maxItems": 1},
+          "id": {
+            "anyOf": [{"minLength": 1}, {"minimum": 1}],
+            "allOf": [{"anyOf": [{"type": "string"}, {"type": "integer"}]}]
+          },
+          "alias": {"oneOf": [{"type": "string", "nullable": true}, {"not": {"type": "integer", "nullable": true}}]},
+          "rank": {"type": "integer", "maximum": 0},
+          "tags": {"type": "array", "items": {"type": "string", "nullable": true}},
+          "toys": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string", "nullable": true}}}},
+          "labels": {"type": "object", "additionalProperties": {"type": "string", "nullable": true}}
+        }
+      }
+    }
+  }
+}`,
+			expectedIssues: []DowngradeIssue{
+				{Pointer: "/components/schemas/Pet/properties/nothing/type", Message: "null type is not supported, replaced by nullable"},
+			},
+		},
+		{
+			desc: "path items",
+			json: `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {"$ref": "#/components/pathItems/Pets"},
+    "/loop": {"$ref": "#/components/pathItems/Loop"}
+  },
+  "components": {
+    "pathItems": {
+      "Pets": {
+        "get": {
+          "parameters": [{"$ref": "#/components/parameters/limit", "description": "max pets"}],
+          "responses": {"200": {"description": "ok"}}
+        }
+      },
+      "Loop": {"$ref": "#/components/pathItems/Loop"},
+      "Unused": {"get": {"responses": {"200": {"description": "ok"}}}}
+    }
+  }
+}`,
+			expected: `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"$ref": "#/components/parameters/limit"}],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/loop": {"$ref": "#/components/pathItems/Loop"}
+  }
+}`,
+			expectedIssues: []DowngradeIssue{
+				{Pointer: "/paths/~1loop", Message: `recursive reference "#/components/pathItems/Loop" cannot be inlined, kept as is`},
+				{Pointer: "/paths/~1pets/get/parameters/0/description", Message: "reference description override is not supported, removed"},
+				{Pointer: "/components/pathItems/Unused", Message: "unreferenced path items are not supported, removed"},
+			},
+		},
+		{
+			desc: "references",
+			json: `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "requestBody": {"$ref": "#/components/requestBodies/NewPet"},
+        "responses": {
+          "201": {"$ref": "#/components/responses/Pet"},
+          "default": {"$ref": "#/components/responses/Error", "description": "An unexpected error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {
+      "NewPet": {"required": true, "content": {"application/json": {"schema": {"type": "object"}}}}
+    },
+    "responses": {
+      "Pet": {"description": "A pet", "content": {"application/json": {"schema": {"type": "object"}}}},
+      "Error": {"description": "An error"}
+    }
+  }
+}`,
+			expected: `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "requestBody": {"$ref": "#/components/requestBodies/NewPet"},
+        "responses": {
+          "201": {"$ref": "#/components/responses/Pet"},
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {
+      "NewPet": {"required": true, "content": {"application/json": {"schema": {"type": "object"}}}}
+    },
+    "responses": {
+      "Pet": {"description": "A pet", "content": {"application/json": {"schema": {"type": "object"}}}},
+      "Error": {"description": "An error"}
+    }
+  }
+}`,
+			expectedIssues: []DowngradeIssue{
+				{
+					Pointer: "/paths/~1pets/post/responses/default/description",
+					Message: "reference description override is not supported, removed",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var oas OpenAPI
+			err := json.Unmarshal([]byte(test.json), &oas)
+			require.NoError(t, err)
+
+			content, issues, err := oas.Downgrade()
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(content))
+			assert.Equal(t, test.expectedIssues, issues)
+		})
+	}
+}
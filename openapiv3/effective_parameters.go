@@ -0,0 +1,119 @@
+package openapiv3
+
+import (
+	"fmt"
+	"net/textproto"
+)
+
+// EffectiveParameters holds the parameters applicable to an operation, grouped by location.
+// In each group, the parameters keep the order of their definition, the ones of the path item first.
+type EffectiveParameters struct {
+	Path   []Parameter
+	Query  []Parameter
+	Header []Parameter
+	Cookie []Parameter
+}
+
+// All returns all the parameters: path, query, header then cookie ones.
+func (ep *EffectiveParameters) All() []Parameter {
+	all := make([]Parameter, 0, len(ep.Path)+len(ep.Query)+len(ep.Header)+len(ep.Cookie))
+	all = append(all, ep.Path...)
+	all = append(all, ep.Query...)
+	all = append(all, ep.Header...)
+	all = append(all, ep.Cookie...)
+
+	return all
+}
+
+// Get returns the parameter with the given location and name.
+func (ep *EffectiveParameters) Get(in, name string) (Parameter, bool) {
+	group := ep.group(in)
+	if group == nil {
+		return Parameter{}, false
+	}
+
+	for _, p := range *group {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Parameter{}, false
+}
+
+func (ep *EffectiveParameters) group(in string) *[]Parameter {
+	switch in {
+	case "path":
+		return &ep.Path
+	case "query":
+		return &ep.Query
+	case "header":
+		return &ep.Header
+	case "cookie":
+		return &ep.Cookie
+	default:
+		return nil
+	}
+}
+
+// set adds a parameter to its group, replacing the one with the same name if any.
+func (ep *EffectiveParameters) set(p Parameter) error {
+	group := ep.group(p.In)
+	if group == nil {
+		return fmt.Errorf("parameter %q: unsupported location %q", p.Name, p.In)
+	}
+
+	for i, existing := range *group {
+		if existing.Name == p.Name {
+			(*group)[i] = p
+			return nil
+		}
+	}
+
+	*group = append(*group, p)
+
+	return nil
+}
+
+// ignoredHeaders lists the header parameters which SHALL be ignored, as they are described by other fields.
+var ignoredHeaders = []string{"Accept", "Content-Type", "Authorization"}
+
+// EffectiveParameters returns the parameters applicable to the operation of the given path and HTTP method:
+// the parameters of the path item, overridden by the operation parameters with the same name and location.
+// The references are resolved and the Accept, Content-Type and Authorization header parameters are ignored.
+func (o *OpenAPI) EffectiveParameters(path, method string) (*EffectiveParameters, error) {
+	pathItem, ok := o.Paths[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown path %q", path)
+	}
+
+	pathItem, err := o.ResolvePathItem(pathItem)
+	if err != nil {
+		return nil, fmt.Errorf("path %q: %w", path, err)
+	}
+
+	op := pathItem.GetOperation(method)
+	if op == nil {
+		return nil, fmt.Errorf("unknown operation %s %q", method, path)
+	}
+
+	ep := &EffectiveParameters{}
+	for _, parameters := range [][]Parameter{pathItem.Parameters, op.Parameters} {
+		for _, p := range parameters {
+			resolved, err := o.ResolveParameter(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s %q: %w", method, path, err)
+			}
+
+			if resolved.In == "header" && containsString(ignoredHeaders, textproto.CanonicalMIMEHeaderKey(resolved.Name)) {
+				continue
+			}
+
+			if err := ep.set(resolved); err != nil {
+				return nil, fmt.Errorf("%s %q: %w", method, path, err)
+			}
+		}
+	}
+
+	return ep, nil
+}
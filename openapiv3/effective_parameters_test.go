@@ -0,0 +1,121 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_EffectiveParameters(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/pets/{petId}": PathItem{
+				Parameters: []Parameter{
+					{Reference: Reference{Ref: "#/components/parameters/petId"}},
+					{Name: "limit", In: "query", Description: "path level"},
+					{Name: "Accept", In: "header"},
+				},
+				Get: &Operation{
+					Parameters: []Parameter{
+						{Name: "limit", In: "query", Description: "operation level"},
+						{Name: "X-Request-ID", In: "header"},
+						{Reference: Reference{Ref: "#/components/parameters/session"}, Description: "overridden"},
+					},
+				},
+				Delete: &Operation{
+					Parameters: []Parameter{
+						{Reference: Reference{Ref: "#/components/parameters/unknown"}},
+					},
+				},
+			},
+			"/pets": PathItem{
+				Reference: Reference{Ref: "#/components/pathItems/pets"},
+			},
+		},
+		Components: &Components{
+			Parameters: map[string]Parameter{
+				"petId":   {Name: "petId", In: "path", Required: boolPtr(true)},
+				"session": {Name: "session", In: "cookie", Description: "session cookie"},
+			},
+			PathItems: map[string]PathItem{
+				"pets": {
+					Parameters: []Parameter{{Name: "limit", In: "query"}},
+					Get:        &Operation{},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		path              string
+		method            string
+		expected          *EffectiveParameters
+		expectedAssertion assert.ErrorAssertionFunc
+	}{
+		{
+			desc:   "overridden and resolved parameters",
+			path:   "/pets/{petId}",
+			method: "GET",
+			expected: &EffectiveParameters{
+				Path:   []Parameter{{Name: "petId", In: "path", Required: boolPtr(true)}},
+				Query:  []Parameter{{Name: "limit", In: "query", Description: "operation level"}},
+				Header: []Parameter{{Name: "X-Request-ID", In: "header"}},
+				Cookie: []Parameter{{Name: "session", In: "cookie", Description: "overridden"}},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:   "referenced path item",
+			path:   "/pets",
+			method: "get",
+			expected: &EffectiveParameters{
+				Query: []Parameter{{Name: "limit", In: "query"}},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "unknown path",
+			path:              "/owners",
+			method:            "GET",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "unknown method",
+			path:              "/pets/{petId}",
+			method:            "POST",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "unresolved reference",
+			path:              "/pets/{petId}",
+			method:            "DELETE",
+			expectedAssertion: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ep, err := oas.EffectiveParameters(test.path, test.method)
+			test.expectedAssertion(t, err)
+			assert.Equal(t, test.expected, ep)
+		})
+	}
+}
+
+func TestEffectiveParameters_Get(t *testing.T) {
+	ep := &EffectiveParameters{Query: []Parameter{{Name: "limit", In: "query"}}}
+
+	p, ok := ep.Get("query", "limit")
+	require.True(t, ok)
+	assert.Equal(t, "limit", p.Name)
+
+	_, ok = ep.Get("header", "limit")
+	assert.False(t, ok)
+
+	assert.Len(t, ep.All(), 1)
+}
@@ -118,6 +118,8 @@ type Example struct {
 	// The value field and externalValue field are mutually exclusive.
 	// See the rules for resolving Relative References (https://spec.openapis.org/oas/latest.html#relativeReferencesURI).
 	ExternalValue string `json:"externalValue,omitempty"`
+	// Extensions are the Specification Extensions of the example.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates an Example.
@@ -0,0 +1,253 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Exchange is an HTTP request and its response, as exchanged with an API.
+// It is the context used to evaluate the runtime expressions.
+type Exchange struct {
+	Request *http.Request
+	// RequestBody is the content of the request body, already read.
+	RequestBody []byte
+	// PathParams holds the values of the path parameters of the request, see MatchPath.
+	PathParams map[string]string
+	Response   *http.Response
+	// ResponseBody is the content of the response body, already read.
+	ResponseBody []byte
+}
+
+// ErrExpressionUndefined is returned when a runtime expression refers to a value missing from the exchange.
+var ErrExpressionUndefined = errors.New("undefined value")
+
+// Evaluate returns the value of a runtime expression within the exchange.
+// The header, query and path values are strings, the status code is an int,
+// and the body values are decoded from JSON (or returned as a string if the body is not JSON).
+func (e *Exchange) Evaluate(expr RuntimeExpression) (any, error) {
+	switch expr.Root {
+	case ExpressionURL:
+		if e.Request == nil || e.Request.URL == nil {
+			return nil, ErrExpressionUndefined
+		}
+		return e.Request.URL.String(), nil
+	case ExpressionMethod:
+		if e.Request == nil {
+			return nil, ErrExpressionUndefined
+		}
+		return e.Request.Method, nil
+	case ExpressionStatusCode:
+		if e.Response == nil {
+			return nil, ErrExpressionUndefined
+		}
+		return e.Response.StatusCode, nil
+	case ExpressionRequest:
+		return e.evaluateRequest(expr)
+	case ExpressionResponse:
+		return e.evaluateResponse(expr)
+	default:
+		return nil, fmt.Errorf("unsupported expression %q", expr)
+	}
+}
+
+func (e *Exchange) evaluateRequest(expr RuntimeExpression) (any, error) {
+	if e.Request == nil {
+		return nil, ErrExpressionUndefined
+	}
+
+	switch expr.Source {
+	case SourceHeader:
+		return headerValue(e.Request.Header, expr.Name)
+	case SourceQuery:
+		values, ok := e.Request.URL.Query()[expr.Name]
+		if !ok || len(values) == 0 {
+			return nil, ErrExpressionUndefined
+		}
+		return values[0], nil
+	case SourcePath:
+		value, ok := e.PathParams[expr.Name]
+		if !ok {
+			return nil, ErrExpressionUndefined
+		}
+		return value, nil
+	case SourceBody:
+		return bodyValue(e.RequestBody, expr.Pointer)
+	default:
+		return nil, fmt.Errorf("unsupported expression %q", expr)
+	}
+}
+
+func (e *Exchange) evaluateResponse(expr RuntimeExpression) (any, error) {
+	if e.Response == nil {
+		return nil, ErrExpressionUndefined
+	}
+
+	switch expr.Source {
+	case SourceHeader:
+		return headerValue(e.Response.Header, expr.Name)
+	case SourceBody:
+		return bodyValue(e.ResponseBody, expr.Pointer)
+	default:
+		return nil, fmt.Errorf("unsupported expression %q: a response has no %s", expr, expr.Source)
+	}
+}
+
+func headerValue(header http.Header, name string) (any, error) {
+	values := header.Values(name)
+	if len(values) == 0 {
+		return nil, ErrExpressionUndefined
+	}
+
+	return values[0], nil
+}
+
+func bodyValue(body []byte, ptr Pointer) (any, error) {
+	if len(body) == 0 {
+		return nil, ErrExpressionUndefined
+	}
+
+	var tree any
+	if err := json.Unmarshal(body, &tree); err != nil {
+		if len(ptr) == 0 {
+			return string(body), nil
+		}
+		return nil, fmt.Errorf("decode body: %w", err)
+	}
+
+	value, ok := lookupTree(tree, ptr.String())
+	if !ok {
+		return nil, ErrExpressionUndefined
+	}
+
+	return value, nil
+}
+
+// Expand returns the template where each expression is replaced by its value within the exchange.
+func (e *Exchange) Expand(t ExpressionTemplate) (string, error) {
+	return t.Expand(func(expr RuntimeExpression) (string, error) {
+		value, err := e.Evaluate(expr)
+		if err != nil {
+			return "", err
+		}
+		return formatValue(value)
+	})
+}
+
+// formatValue returns the string form of a value: as is for the scalars, as JSON for the objects and arrays.
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case map[string]any, []any:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("marshal value: %w", err)
+		}
+		return string(raw), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+var pathTemplateParam = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// pathRegexp is the compiled pattern of a path template with the names of its parameters.
+type pathRegexp struct {
+	re    *regexp.Regexp
+	names []string
+}
+
+// compilePathRegexp compiles the pattern of a path template.
+func compilePathRegexp(template string) (pathRegexp, error) {
+	pattern, names := pathPattern(template)
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return pathRegexp{}, err
+	}
+	return pathRegexp{re: re, names: names}, nil
+}
+
+// match returns the values of the template parameters in a URL path, if it matches.
+func (p pathRegexp) match(path string) (map[string]string, bool) {
+	matches := p.re.FindStringSubmatch(path)
+	if matches == nil {
+		return nil, false
+	}
+
+	params := make(map[string]string, len(p.names))
+	for i, name := range p.names {
+		value, err := url.PathUnescape(matches[i+1])
+		if err != nil {
+			return nil, false
+		}
+		params[name] = value
+	}
+
+	return params, true
+}
+
+// pathMatcher matches URL paths against path templates, compiling each template once.
+// Its cache is scoped to the matcher, so it only holds the templates of the document it serves.
+// The zero value is ready to use and safe for concurrent use.
+type pathMatcher struct {
+	mu      sync.Mutex
+	regexps map[string]pathRegexp
+}
+
+// match reports whether the path of a URL matches a path template and returns the values of the template parameters.
+func (m *pathMatcher) match(template, path string) (map[string]string, bool) {
+	m.mu.Lock()
+	compiled, ok := m.regexps[template]
+	if !ok {
+		var err error
+		if compiled, err = compilePathRegexp(template); err != nil {
+			m.mu.Unlock()
+			return nil, false
+		}
+		if m.regexps == nil {
+			m.regexps = make(map[string]pathRegexp)
+		}
+		m.regexps[template] = compiled
+	}
+	m.mu.Unlock()
+
+	return compiled.match(path)
+}
+
+// MatchPath reports whether the path of a URL matches a path template, like /pets/{petId},
+// and returns the values of the template parameters.
+func MatchPath(template, path string) (map[string]string, bool) {
+	compiled, err := compilePathRegexp(template)
+	if err != nil {
+		return nil, false
+	}
+	return compiled.match(path)
+}
+
+// pathPattern returns the regular expression matching the escaped paths of a path template,
+// capturing the values of its parameters, and the names of the parameters.
+func pathPattern(template string) (string, []string) {
+	var (
+		pattern strings.Builder
+		names   []string
+		last    int
+	)
+
+	pattern.WriteByte('^')
+	for _, loc := range pathTemplateParam.FindAllStringSubmatchIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString(`([^/]+)`)
+		names = append(names, template[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteByte('$')
+
+	return pattern.String(), names
+}
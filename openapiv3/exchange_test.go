@@ -0,0 +1,150 @@
+package openapiv3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchange_Evaluate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/pets/42?limit=10", nil)
+	req.Header.Set("X-Request-ID", "abc")
+
+	exchange := &Exchange{
+		Request:     req,
+		RequestBody: []byte(`{"name":"Rex","tags":["dog"]}`),
+		PathParams:  map[string]string{"petId": "42"},
+		Response: &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Location": []string{"/pets/42"}},
+		},
+		ResponseBody: []byte(`{"id":42,"owner":{"name":"Bob"}}`),
+	}
+
+	tests := []struct {
+		desc              string
+		expr              string
+		expected          any
+		expectedAssertion assert.ErrorAssertionFunc
+	}{
+		{desc: "url", expr: "$url", expected: "http://example.com/pets/42?limit=10", expectedAssertion: assert.NoError},
+		{desc: "method", expr: "$method", expected: "POST", expectedAssertion: assert.NoError},
+		{desc: "status code", expr: "$statusCode", expected: 201, expectedAssertion: assert.NoError},
+		{desc: "request header", expr: "$request.header.x-request-id", expected: "abc", expectedAssertion: assert.NoError},
+		{desc: "request query", expr: "$request.query.limit", expected: "10", expectedAssertion: assert.NoError},
+		{desc: "request path", expr: "$request.path.petId", expected: "42", expectedAssertion: assert.NoError},
+		{desc: "request body pointer", expr: "$request.body#/tags/0", expected: "dog", expectedAssertion: assert.NoError},
+		{
+			desc:              "whole request body",
+			expr:              "$request.body",
+			expected:          map[string]any{"name": "Rex", "tags": []any{"dog"}},
+			expectedAssertion: assert.NoError,
+		},
+		{desc: "response header", expr: "$response.header.Location", expected: "/pets/42", expectedAssertion: assert.NoError},
+		{desc: "response body pointer", expr: "$response.body#/owner/name", expected: "Bob", expectedAssertion: assert.NoError},
+		{desc: "missing header", expr: "$request.header.X-Unknown", expectedAssertion: errorIs(ErrExpressionUndefined)},
+		{desc: "missing body value", expr: "$response.body#/unknown", expectedAssertion: errorIs(ErrExpressionUndefined)},
+		{desc: "response query", expr: "$response.query.limit", expectedAssertion: assert.Error},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := ParseRuntimeExpression(test.expr)
+			require.NoError(t, err)
+
+			value, err := exchange.Evaluate(expr)
+			test.expectedAssertion(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestExchange_Expand(t *testing.T) {
+	exchange := &Exchange{
+		Request:     httptest.NewRequest(http.MethodPost, "http://example.com/subscribe", nil),
+		RequestBody: []byte(`{"id":7,"email":"bob@example.com","filter":{"kind":"cat"}}`),
+	}
+
+	tmpl, err := ParseExpressionTemplate("{$request.body#/id}/{$request.body#/email}?filter={$request.body#/filter}")
+	require.NoError(t, err)
+
+	got, err := exchange.Expand(tmpl)
+	require.NoError(t, err)
+
+	assert.Equal(t, `7/bob@example.com?filter={"kind":"cat"}`, got)
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		desc     string
+		template string
+		path     string
+		expected map[string]string
+		matched  bool
+	}{
+		{desc: "no parameter", template: "/pets", path: "/pets", expected: map[string]string{}, matched: true},
+		{
+			desc:     "parameters",
+			template: "/pets/{petId}/toys/{toyId}",
+			path:     "/pets/42/toys/ball",
+			expected: map[string]string{"petId": "42", "toyId": "ball"},
+			matched:  true,
+		},
+		{
+			desc:     "escaped value",
+			template: "/files/{name}.json",
+			path:     "/files/my%20file.json",
+			expected: map[string]string{"name": "my file"},
+			matched:  true,
+		},
+		{desc: "segment mismatch", template: "/pets/{petId}", path: "/pets/42/toys"},
+		{desc: "literal mismatch", template: "/pets/{petId}", path: "/toys/42"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			params, ok := MatchPath(test.template, test.path)
+			assert.Equal(t, test.matched, ok)
+			assert.Equal(t, test.expected, params)
+		})
+	}
+}
+
+func TestPathMatcher(t *testing.T) {
+	t.Parallel()
+
+	var matcher pathMatcher
+	template := "/owners/{ownerId}"
+
+	_, ok := matcher.match(template, "/owners/1")
+	require.True(t, ok)
+	cached, ok := matcher.regexps[template]
+	require.True(t, ok)
+
+	params, ok := matcher.match(template, "/owners/2")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"ownerId": "2"}, params)
+
+	// The pattern is compiled once per template, and only cached by the matcher.
+	assert.Same(t, cached.re, matcher.regexps[template].re)
+	assert.Len(t, matcher.regexps, 1)
+
+	_, ok = MatchPath("/visits/{visitId}", "/visits/1")
+	require.True(t, ok)
+	assert.Len(t, matcher.regexps, 1)
+}
+
+func errorIs(target error) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+		return assert.ErrorIs(t, err, target, msgAndArgs...)
+	}
+}
@@ -0,0 +1,315 @@
+package openapiv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Extensions are the [Specification Extensions] of an object: the fields whose names start with x-,
+// with their JSON values.
+//
+// [Specification Extensions]: https://spec.openapis.org/oas/latest.html#specificationExtensions
+type Extensions map[string]any
+
+// Has reports whether the extension is set to a value other than null and false.
+func (e Extensions) Has(name string) bool {
+	switch v := e[name].(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+// Strings returns the value of an extension holding a string or a list of strings.
+func (e Extensions) Strings(name string) []string {
+	switch v := e[name].(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// marshalExtensible encodes the plain version of an object, without JSON methods, followed by its extensions.
+func marshalExtensible(plain any, extensions Extensions) ([]byte, error) {
+	data, err := json.Marshal(plain)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	var b bytes.Buffer
+	b.Write(bytes.TrimSuffix(data, []byte("}")))
+	for _, name := range sortedKeys(extensions) {
+		if !strings.HasPrefix(name, "x-") {
+			return nil, fmt.Errorf("extension %q must start with x-", name)
+		}
+
+		value, err := json.Marshal(extensions[name])
+		if err != nil {
+			return nil, fmt.Errorf("extension %q: %w", name, err)
+		}
+
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// marshalReferable encodes an object which may be a Reference Object: as marshalExtensible does without $ref,
+// and with only the $ref and the non empty summary and description overriding the ones of its target otherwise.
+func marshalReferable(ref string, plain any, extensions Extensions) ([]byte, error) {
+	if ref == "" {
+		return marshalExtensible(plain, extensions)
+	}
+
+	data, err := json.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	reference := map[string]any{"$ref": ref}
+	for _, name := range []string{"summary", "description"} {
+		if value, _ := fields[name].(string); value != "" {
+			reference[name] = value
+		}
+	}
+
+	return json.Marshal(reference)
+}
+
+// unmarshalExtensible decodes an object into its plain version, without JSON methods, and its extensions.
+func unmarshalExtensible(data []byte, plain any, extensions *Extensions) error {
+	if err := json.Unmarshal(data, plain); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*extensions = nil
+	for name, raw := range fields {
+		if !strings.HasPrefix(name, "x-") {
+			continue
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("extension %q: %w", name, err)
+		}
+		if *extensions == nil {
+			*extensions = Extensions{}
+		}
+		(*extensions)[name] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the document along with its extensions.
+func (o OpenAPI) MarshalJSON() ([]byte, error) {
+	type plain OpenAPI
+	return marshalExtensible(plain(o), o.Extensions)
+}
+
+// UnmarshalJSON decodes the document and its extensions.
+func (o *OpenAPI) UnmarshalJSON(data []byte) error {
+	type plain OpenAPI
+	return unmarshalExtensible(data, (*plain)(o), &o.Extensions)
+}
+
+// MarshalJSON encodes the info along with its extensions.
+func (i Info) MarshalJSON() ([]byte, error) {
+	type plain Info
+	return marshalExtensible(plain(i), i.Extensions)
+}
+
+// UnmarshalJSON decodes the info and its extensions.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type plain Info
+	return unmarshalExtensible(data, (*plain)(i), &i.Extensions)
+}
+
+// MarshalJSON encodes the server along with its extensions.
+func (s Server) MarshalJSON() ([]byte, error) {
+	type plain Server
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes the server and its extensions.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
+}
+
+// MarshalJSON encodes the path item along with its extensions.
+func (pi PathItem) MarshalJSON() ([]byte, error) {
+	type plain PathItem
+	return marshalExtensible(plain(pi), pi.Extensions)
+}
+
+// UnmarshalJSON decodes the path item and its extensions.
+func (pi *PathItem) UnmarshalJSON(data []byte) error {
+	type plain PathItem
+	return unmarshalExtensible(data, (*plain)(pi), &pi.Extensions)
+}
+
+// MarshalJSON encodes the operation along with its extensions.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	type plain Operation
+	return marshalExtensible(plain(op), op.Extensions)
+}
+
+// UnmarshalJSON decodes the operation and its extensions.
+func (op *Operation) UnmarshalJSON(data []byte) error {
+	type plain Operation
+	return unmarshalExtensible(data, (*plain)(op), &op.Extensions)
+}
+
+// MarshalJSON encodes the parameter, or the header, along with its extensions, or its reference.
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalReferable(p.Ref, plain(p), p.Extensions)
+}
+
+// UnmarshalJSON decodes the parameter, or the header, and its extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	return unmarshalExtensible(data, (*plain)(p), &p.Extensions)
+}
+
+// MarshalJSON encodes the request body along with its extensions, or its reference.
+func (rb RequestBody) MarshalJSON() ([]byte, error) {
+	type plain RequestBody
+	return marshalReferable(rb.Ref, plain(rb), rb.Extensions)
+}
+
+// UnmarshalJSON decodes the request body and its extensions.
+func (rb *RequestBody) UnmarshalJSON(data []byte) error {
+	type plain RequestBody
+	return unmarshalExtensible(data, (*plain)(rb), &rb.Extensions)
+}
+
+// MarshalJSON encodes the response along with its extensions, or its reference.
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return marshalReferable(r.Ref, plain(r), r.Extensions)
+}
+
+// UnmarshalJSON decodes the response and its extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	return unmarshalExtensible(data, (*plain)(r), &r.Extensions)
+}
+
+// MarshalJSON encodes the media type along with its extensions.
+func (m MediaType) MarshalJSON() ([]byte, error) {
+	type plain MediaType
+	return marshalExtensible(plain(m), m.Extensions)
+}
+
+// UnmarshalJSON decodes the media type and its extensions.
+func (m *MediaType) UnmarshalJSON(data []byte) error {
+	type plain MediaType
+	return unmarshalExtensible(data, (*plain)(m), &m.Extensions)
+}
+
+// MarshalJSON encodes the example along with its extensions, or its reference.
+func (ex Example) MarshalJSON() ([]byte, error) {
+	type plain Example
+	return marshalReferable(ex.Ref, plain(ex), ex.Extensions)
+}
+
+// UnmarshalJSON decodes the example and its extensions.
+func (ex *Example) UnmarshalJSON(data []byte) error {
+	type plain Example
+	return unmarshalExtensible(data, (*plain)(ex), &ex.Extensions)
+}
+
+// MarshalJSON encodes the link along with its extensions, or its reference.
+func (l Link) MarshalJSON() ([]byte, error) {
+	type plain Link
+	return marshalReferable(l.Ref, plain(l), l.Extensions)
+}
+
+// UnmarshalJSON decodes the link and its extensions.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type plain Link
+	return unmarshalExtensible(data, (*plain)(l), &l.Extensions)
+}
+
+// MarshalJSON encodes the components along with their extensions.
+func (c Components) MarshalJSON() ([]byte, error) {
+	type plain Components
+	return marshalExtensible(plain(c), c.Extensions)
+}
+
+// UnmarshalJSON decodes the components and their extensions.
+func (c *Components) UnmarshalJSON(data []byte) error {
+	type plain Components
+	return unmarshalExtensible(data, (*plain)(c), &c.Extensions)
+}
+
+// MarshalJSON encodes the schema along with its extensions.
+func (sc Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	return marshalExtensible(plain(sc), sc.Extensions)
+}
+
+// UnmarshalJSON decodes the schema and its extensions.
+func (sc *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	return unmarshalExtensible(data, (*plain)(sc), &sc.Extensions)
+}
+
+// MarshalJSON encodes the security scheme along with its extensions.
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	type plain SecurityScheme
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes the security scheme and its extensions.
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type plain SecurityScheme
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
+}
+
+// MarshalJSON encodes the tag along with its extensions.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+	return marshalExtensible(plain(t), t.Extensions)
+}
+
+// UnmarshalJSON decodes the tag and its extensions.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	return unmarshalExtensible(data, (*plain)(t), &t.Extensions)
+}
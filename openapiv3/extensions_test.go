@@ -0,0 +1,104 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtensions_JSON(t *testing.T) {
+	data := []byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1.0.0", "x-logo": {"url": "logo.png"}},
+		"paths": {
+			"/pets": {
+				"x-internal": true,
+				"get": {
+					"operationId": "listPets",
+					"x-lint-ignore": ["operation-summary"],
+					"parameters": [{"name": "limit", "in": "query", "x-example": 10}],
+					"responses": {"200": {"description": "OK", "x-cache": "public"}}
+				}
+			}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "x-go-type": "Pet"}}},
+		"x-api-id": "pets"
+	}`)
+
+	var oas OpenAPI
+	require.NoError(t, json.Unmarshal(data, &oas))
+
+	assert.Equal(t, Extensions{"x-api-id": "pets"}, oas.Extensions)
+	assert.Equal(t, Extensions{"x-logo": map[string]any{"url": "logo.png"}}, oas.Info.Extensions)
+	assert.True(t, oas.Paths["/pets"].Extensions.Has("x-internal"))
+
+	op := oas.Paths["/pets"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "listPets", op.OperationID)
+	assert.Equal(t, []string{"operation-summary"}, op.Extensions.Strings("x-lint-ignore"))
+	assert.Equal(t, Extensions{"x-example": 10.0}, op.Parameters[0].Extensions)
+	assert.Equal(t, Extensions{"x-cache": "public"}, (*op.Responses)["200"].Extensions)
+	assert.Equal(t, Extensions{"x-go-type": "Pet"}, oas.Components.Schemas["Pet"].Extensions)
+
+	node, err := oas.Lookup("/paths/~1pets/get/x-lint-ignore/0")
+	require.NoError(t, err)
+	assert.Equal(t, "operation-summary", node)
+
+	encoded, err := json.Marshal(oas)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(encoded))
+
+	encoded, err = json.Marshal(Tag{Name: "pets", Extensions: Extensions{"x-order": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"pets","x-order":1}`, string(encoded))
+
+	_, err = json.Marshal(Tag{Name: "pets", Extensions: Extensions{"order": 1}})
+	assert.Error(t, err)
+}
+
+func TestReference_JSON(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    any
+		expected string
+	}{
+		{
+			desc:     "request body",
+			value:    RequestBody{Reference: Reference{Ref: "#/components/requestBodies/Pet"}},
+			expected: `{"$ref":"#/components/requestBodies/Pet"}`,
+		},
+		{
+			desc:     "response with a description override",
+			value:    Response{Reference: Reference{Ref: "#/components/responses/Error"}, Description: "An error"},
+			expected: `{"$ref":"#/components/responses/Error","description":"An error"}`,
+		},
+		{
+			desc:     "parameter",
+			value:    Parameter{Reference: Reference{Ref: "#/components/parameters/limit"}, Extensions: Extensions{"x-ignored": true}},
+			expected: `{"$ref":"#/components/parameters/limit"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := json.Marshal(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(encoded))
+		})
+	}
+}
+
+func TestExtensions_Has(t *testing.T) {
+	extensions := Extensions{"x-true": true, "x-false": false, "x-null": nil, "x-string": "yes"}
+
+	assert.True(t, extensions.Has("x-true"))
+	assert.False(t, extensions.Has("x-false"))
+	assert.False(t, extensions.Has("x-null"))
+	assert.True(t, extensions.Has("x-string"))
+	assert.False(t, extensions.Has("x-missing"))
+}
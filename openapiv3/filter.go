@@ -0,0 +1,150 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OperationFilter selects the operations kept by OpenAPI.Filter.
+// An operation is kept when it matches all the criteria that are set.
+type OperationFilter struct {
+	// Tags keeps the operations having at least one of the tags.
+	Tags []string
+	// PathPrefixes keeps the operations of the paths starting with one of the prefixes, segment-wise:
+	// /pets matches /pets and /pets/{petId} but not /petstore.
+	// The webhooks are not filtered by path.
+	PathPrefixes []string
+	// Methods keeps the operations of the HTTP methods, case-insensitive.
+	Methods []string
+	// ExcludeExtensions removes the operations, and the path items, having one of the extensions,
+	// set to a value other than null and false, e.g. x-internal.
+	ExcludeExtensions []string
+	// Match keeps the operations for which it returns true.
+	// The path item of the operation is resolved, and the pointer of the operation is the one of the filtered document.
+	Match func(po PathOperation) bool
+}
+
+// Filter returns a copy of the document keeping only the operations of the paths and the webhooks
+// selected by the filter, along with their callbacks.
+// The path items left without operations are removed, the referenced path items partially kept are inlined,
+// the declared tags only used by the removed operations are removed, and so are the unused components,
+// see PruneComponents.
+func (o *OpenAPI) Filter(filter OperationFilter) (*OpenAPI, error) {
+	doc, err := o.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	removedTags := map[string]bool{}
+	filterPathItems := func(field string, pathItems map[string]PathItem, byPath bool) error {
+		for _, key := range sortedKeys(pathItems) {
+			ptr := appendPointer("/"+field, key)
+			pathItem, err := doc.ResolvePathItem(pathItems[key])
+			if err != nil {
+				return fmt.Errorf("%s: %w", ptr, err)
+			}
+
+			removed := false
+			for _, mo := range pathItem.Operations() {
+				po := PathOperation{MethodOperation: mo, Path: key, Pointer: appendPointer(ptr, strings.ToLower(mo.Method))}
+				if filter.keeps(po, &pathItem, byPath) {
+					continue
+				}
+
+				for _, tag := range mo.Operation.Tags {
+					removedTags[tag] = true
+				}
+				_ = pathItem.DeleteOperation(mo.Method)
+				removed = true
+			}
+
+			switch {
+			case len(pathItem.Operations()) == 0:
+				delete(pathItems, key)
+			case removed:
+				pathItems[key] = pathItem
+			}
+		}
+
+		return nil
+	}
+
+	if err = filterPathItems("paths", doc.Paths, true); err != nil {
+		return nil, err
+	}
+	if err = filterPathItems("webhooks", doc.Webhooks, false); err != nil {
+		return nil, err
+	}
+
+	usedTags := map[string]bool{}
+	for _, pathItems := range []map[string]PathItem{doc.Paths, doc.Webhooks} {
+		for _, pathItem := range pathItems {
+			// The references of the path items have been resolved without error above.
+			pathItem, _ = doc.ResolvePathItem(pathItem)
+			for _, mo := range pathItem.Operations() {
+				for _, tag := range mo.Operation.Tags {
+					usedTags[tag] = true
+				}
+			}
+		}
+	}
+
+	var tags []Tag
+	for _, tag := range doc.Tags {
+		if usedTags[tag.Name] || !removedTags[tag.Name] {
+			tags = append(tags, tag)
+		}
+	}
+	doc.Tags = tags
+
+	doc.PruneComponents()
+
+	return doc, nil
+}
+
+// keeps reports whether the operation of a resolved path item matches the filter.
+func (f OperationFilter) keeps(po PathOperation, pathItem *PathItem, byPath bool) bool {
+	if len(f.Tags) > 0 && !containsAny(po.Operation.Tags, f.Tags) {
+		return false
+	}
+
+	if byPath && len(f.PathPrefixes) > 0 {
+		matched := false
+		for _, prefix := range f.PathPrefixes {
+			prefix = strings.TrimSuffix(prefix, "/")
+			matched = matched || po.Path == prefix || strings.HasPrefix(po.Path, prefix+"/")
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Methods) > 0 {
+		matched := false
+		for _, method := range f.Methods {
+			matched = matched || strings.EqualFold(method, po.Method)
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, extension := range f.ExcludeExtensions {
+		if pathItem.Extensions.Has(extension) || po.Operation.Extensions.Has(extension) {
+			return false
+		}
+	}
+
+	return f.Match == nil || f.Match(po)
+}
+
+// containsAny reports whether a slice contains one of the values.
+func containsAny(s, values []string) bool {
+	for _, v := range values {
+		if containsString(s, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package openapiv3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Filter(t *testing.T) {
+	tests := []struct {
+		desc               string
+		filter             OperationFilter
+		expectedOperations []string
+		expectedTags       []string
+		expectedComponents []string
+	}{
+		{
+			desc:   "no filter",
+			filter: OperationFilter{},
+			expectedOperations: []string{
+				"/paths/~1admin/get", "/paths/~1pets/get", "/paths/~1pets/post", "/paths/~1pets~1{petId}/get",
+				"/paths/~1pets~1{petId}/delete", "/paths/~1petstore/get", "/webhooks/newPet/post",
+			},
+			expectedTags: []string{"pets", "admin", "misc"},
+			expectedComponents: []string{
+				"#/components/schemas/NewPet", "#/components/schemas/Pet", "#/components/responses/Pets",
+				"#/components/securitySchemes/basic", "#/components/callbacks/onEvent", "#/components/pathItems/Pet",
+			},
+		},
+		{
+			desc:   "exclude extension",
+			filter: OperationFilter{ExcludeExtensions: []string{"x-internal"}},
+			expectedOperations: []string{
+				"/paths/~1pets/get", "/paths/~1pets~1{petId}/get", "/paths/~1pets~1{petId}/delete", "/paths/~1petstore/get",
+				"/webhooks/newPet/post",
+			},
+			expectedTags: []string{"pets", "admin", "misc"},
+			expectedComponents: []string{
+				"#/components/schemas/Pet", "#/components/responses/Pets", "#/components/callbacks/onEvent",
+				"#/components/pathItems/Pet",
+			},
+		},
+		{
+			desc:               "tags",
+			filter:             OperationFilter{Tags: []string{"admin"}},
+			expectedOperations: []string{"/paths/~1admin/get", "/paths/~1pets/post", "/paths/~1pets~1{petId}/delete"},
+			expectedTags:       []string{"pets", "admin", "misc"},
+			expectedComponents: []string{
+				"#/components/schemas/NewPet", "#/components/securitySchemes/basic", "#/components/callbacks/onEvent",
+			},
+		},
+		{
+			desc:               "tags and extension",
+			filter:             OperationFilter{Tags: []string{"pets"}, ExcludeExtensions: []string{"x-internal"}},
+			expectedOperations: []string{"/paths/~1pets/get", "/paths/~1pets~1{petId}/get", "/paths/~1petstore/get", "/webhooks/newPet/post"},
+			expectedTags:       []string{"pets", "misc"},
+			expectedComponents: []string{"#/components/schemas/Pet", "#/components/responses/Pets", "#/components/callbacks/onEvent"},
+		},
+		{
+			desc:               "path prefixes",
+			filter:             OperationFilter{PathPrefixes: []string{"/pets/"}},
+			expectedOperations: []string{"/paths/~1pets/get", "/paths/~1pets/post", "/paths/~1pets~1{petId}/get", "/paths/~1pets~1{petId}/delete", "/webhooks/newPet/post"},
+			expectedTags:       []string{"pets", "admin", "misc"},
+			expectedComponents: []string{
+				"#/components/schemas/NewPet", "#/components/schemas/Pet", "#/components/responses/Pets",
+				"#/components/callbacks/onEvent", "#/components/pathItems/Pet",
+			},
+		},
+		{
+			desc:               "methods",
+			filter:             OperationFilter{Methods: []string{"post", "DELETE"}},
+			expectedOperations: []string{"/paths/~1pets/post", "/paths/~1pets~1{petId}/delete", "/webhooks/newPet/post"},
+			expectedTags:       []string{"pets", "admin", "misc"},
+			expectedComponents: []string{"#/components/schemas/NewPet", "#/components/callbacks/onEvent"},
+		},
+		{
+			desc: "match",
+			filter: OperationFilter{Match: func(po PathOperation) bool {
+				return po.Operation.OperationID == "listPets"
+			}},
+			expectedOperations: []string{"/paths/~1pets/get"},
+			expectedTags:       []string{"pets", "misc"},
+			expectedComponents: []string{"#/components/schemas/Pet", "#/components/responses/Pets", "#/components/callbacks/onEvent"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			oas, err := FromFile("testdata/filter.yaml")
+			require.NoError(t, err)
+
+			filtered, err := oas.Filter(test.filter)
+			require.NoError(t, err)
+			require.NoError(t, filtered.Validate())
+
+			var operations []string
+			for _, pathItem := range []map[string]PathItem{filtered.Paths, filtered.Webhooks} {
+				for key, item := range pathItem {
+					resolved, err := filtered.ResolvePathItem(item)
+					require.NoError(t, err)
+					for _, mo := range resolved.Operations() {
+						root := "/paths"
+						if _, ok := filtered.Webhooks[key]; ok {
+							root = "/webhooks"
+						}
+						operations = append(operations, appendPointer(root, key, strings.ToLower(mo.Method)))
+					}
+				}
+			}
+			assert.ElementsMatch(t, test.expectedOperations, operations)
+
+			var tags []string
+			for _, tag := range filtered.Tags {
+				tags = append(tags, tag.Name)
+			}
+			assert.Equal(t, test.expectedTags, tags)
+
+			assert.Equal(t, test.expectedComponents, filtered.UsedComponents())
+			assert.Empty(t, filtered.UnusedComponents())
+
+			// The source document is left untouched.
+			assert.Len(t, oas.Operations(), 5)
+			pathItem := oas.Components.PathItems["Pet"]
+			assert.Len(t, pathItem.Operations(), 2)
+		})
+	}
+}
@@ -0,0 +1,532 @@
+package openapiv3
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default bounds of the Generator.
+const (
+	defaultGeneratorMaxDepth    = 4
+	defaultGeneratorArrayLength = 3
+	defaultGeneratorStringLen   = 12
+	defaultGeneratorRange       = 1000
+	// generatorAttempts is the number of values generated before giving up on a schema the values must satisfy.
+	generatorAttempts = 20
+)
+
+// Generator generates random values valid against the schemas of a document,
+// for fixtures, request fuzzing or mock responses.
+// The values are deterministic for a given seed. A Generator is safe for concurrent use.
+//
+// The generated values honor the type, format (date-time, date, uuid, email, ipv4, ipv6, uri, hostname), enum,
+// bounds, pattern, array bounds and required properties keywords, and the allOf, anyOf, oneOf and not compositions.
+// Each value is validated before being returned, and regenerated if invalid, e.g. when it matches several schemas
+// of a oneOf.
+type Generator struct {
+	// MaxDepth is the nesting depth beyond which the optional properties are omitted
+	// and the arrays have their minimum length, to bound the values of recursive schemas. 4 by default.
+	MaxDepth int
+	// ArrayLength is the maximum length of the arrays without maxItems, above their minItems. 3 by default.
+	ArrayLength int
+	// StringLength is the maximum length of the strings without maxLength, above their minLength. 12 by default.
+	StringLength int
+
+	doc  *OpenAPI
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewGenerator returns a Generator of values for the schemas of a document, seeded with the given value.
+func NewGenerator(doc *OpenAPI, seed int64) *Generator {
+	return &Generator{
+		MaxDepth:     defaultGeneratorMaxDepth,
+		ArrayLength:  defaultGeneratorArrayLength,
+		StringLength: defaultGeneratorStringLen,
+		doc:          doc,
+		rand:         rand.New(rand.NewSource(seed)), //nolint:gosec // The values are fixtures, not secrets.
+	}
+}
+
+// Generate returns a random value valid against a schema, as it would be decoded from JSON.
+func (g *Generator) Generate(schema Schema) (any, error) {
+	return g.GenerateJSONSchema(schema.JSONSchema)
+}
+
+// GenerateJSONSchema returns a random value valid against a JSON schema, as it would be decoded from JSON.
+func (g *Generator) GenerateJSONSchema(schema JSONSchema) (any, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var err error
+	for i := 0; i < generatorAttempts; i++ {
+		var value any
+		if value, err = g.generate(schema, 0); err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		if err = g.doc.ValidateValue(schema, value); err == nil {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("generate: no valid value after %d attempts: %w", generatorAttempts, err)
+}
+
+func (g *Generator) generate(schema JSONSchema, depth int) (any, error) {
+	schema, err := g.doc.flattenSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if branches := append(append([]JSONSchema(nil), schema.AnyOf...), schema.OneOf...); len(branches) > 0 {
+		base := schema
+		base.AnyOf, base.OneOf = nil, nil
+		merged, err := g.doc.flattenSchema(JSONSchema{AllOf: []JSONSchema{base, branches[g.rand.Intn(len(branches))]}})
+		if err != nil {
+			return nil, err
+		}
+		return g.generate(merged, depth)
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[g.rand.Intn(len(schema.Enum))], nil
+	}
+
+	switch g.schemaType(schema) {
+	case "object":
+		return g.generateObject(schema, depth)
+	case "array":
+		return g.generateArray(schema, depth)
+	case "string":
+		return g.generateString(schema)
+	case "integer":
+		return g.generateNumber(schema, true)
+	case "number":
+		return g.generateNumber(schema, false)
+	case "boolean":
+		return g.rand.Intn(2) == 1, nil
+	default:
+		return nil, nil
+	}
+}
+
+// schemaType returns a random type of the schema, or the type implied by its keywords if it has none.
+func (g *Generator) schemaType(schema JSONSchema) string {
+	if types := schema.Types(); len(types) > 0 {
+		return types[g.rand.Intn(len(types))]
+	}
+
+	switch {
+	case len(schema.Properties) > 0 || len(schema.Required) > 0:
+		return "object"
+	case schema.Items != nil || schema.MinItems > 0 || schema.MaxItems > 0:
+		return "array"
+	case schema.Minimum != nil || schema.ExclusiveMinimum != nil || schema.ExclusiveMaximum != nil || schema.Maximum != nil:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func (g *Generator) generateObject(schema JSONSchema, depth int) (map[string]any, error) {
+	object := map[string]any{}
+	for _, name := range sortedKeys(schema.Properties) {
+		if !containsString(schema.Required, name) && (depth >= g.MaxDepth || g.rand.Intn(2) == 0) {
+			continue
+		}
+
+		value, err := g.generate(schema.Properties[name], depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		object[name] = value
+	}
+
+	// The required properties without schema accept any value.
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			object[name] = g.letters(1, g.StringLength)
+		}
+	}
+
+	return object, nil
+}
+
+func (g *Generator) generateArray(schema JSONSchema, depth int) ([]any, error) {
+	length := schema.MinItems
+	if depth < g.MaxDepth {
+		maxItems := schema.MaxItems
+		if maxItems == 0 {
+			maxItems = schema.MinItems + g.ArrayLength
+		}
+		length = g.between(schema.MinItems, maxItems)
+	}
+
+	items := make([]any, 0, length)
+	for i := 0; i < length; i++ {
+		item, err := g.generate(schema.itemsSchema(), depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (g *Generator) generateString(schema JSONSchema) (string, error) {
+	if s, ok := g.formatString(schema.Format); ok {
+		return s, nil
+	}
+
+	if schema.Pattern != "" {
+		return g.patternString(schema.Pattern)
+	}
+
+	maxLength := schema.MaxLength
+	if maxLength == 0 {
+		maxLength = schema.MinLength + g.StringLength
+	}
+
+	return g.letters(schema.MinLength, maxLength), nil
+}
+
+// generateNumber returns a number within the bounds of the schema.
+// Without bounds, the numbers are in [0, 1000], or in a range of 1000 from the single defined bound.
+func (g *Generator) generateNumber(schema JSONSchema, integer bool) (any, error) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if schema.Minimum != nil {
+		lo = *schema.Minimum
+	}
+	if exclusive := schema.exclusiveMinimum(); exclusive != nil && *exclusive >= lo {
+		lo = math.Nextafter(*exclusive, math.Inf(1))
+	}
+	if schema.Maximum != nil {
+		hi = *schema.Maximum
+	}
+	if exclusive := schema.exclusiveMaximum(); exclusive != nil && *exclusive <= hi {
+		hi = math.Nextafter(*exclusive, math.Inf(-1))
+	}
+
+	switch {
+	case math.IsInf(lo, -1) && math.IsInf(hi, 1):
+		lo, hi = 0, defaultGeneratorRange
+	case math.IsInf(lo, -1):
+		lo = hi - defaultGeneratorRange
+	case math.IsInf(hi, 1):
+		hi = lo + defaultGeneratorRange
+	}
+
+	if integer {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("no value between %v and %v", lo, hi)
+	}
+
+	if integer {
+		return int64(lo) + g.rand.Int63n(int64(hi-lo)+1), nil
+	}
+
+	return lo + g.rand.Float64()*(hi-lo), nil
+}
+
+// formatString returns a random string of a well-known format.
+func (g *Generator) formatString(format string) (string, bool) {
+	switch format {
+	case "date-time":
+		return g.time().Format(time.RFC3339), true
+	case "date":
+		return g.time().Format(time.DateOnly), true
+	case "uuid":
+		b := make([]byte, 16)
+		_, _ = g.rand.Read(b)
+		b[6] = b[6]&0x0f | 0x40 // Version 4.
+		b[8] = b[8]&0x3f | 0x80 // Variant RFC 4122.
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), true
+	case "email":
+		return g.letters(3, 10) + "@example.com", true
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", g.rand.Intn(256), g.rand.Intn(256), g.rand.Intn(256), g.rand.Intn(256)), true
+	case "ipv6":
+		groups := make([]string, 8)
+		for i := range groups {
+			groups[i] = fmt.Sprintf("%x", g.rand.Intn(0x10000))
+		}
+		return strings.Join(groups, ":"), true
+	case "uri":
+		return "https://example.com/" + g.letters(3, 10), true
+	case "hostname":
+		return g.letters(3, 10) + ".example.com", true
+	default:
+		return "", false
+	}
+}
+
+// time returns a random time between 2000 and 2030, to the second.
+func (g *Generator) time() time.Time {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	return time.Unix(start+g.rand.Int63n(end-start), 0).UTC()
+}
+
+const generatorLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// letters returns a random string of lower case letters with a length in [minLength, maxLength].
+func (g *Generator) letters(minLength, maxLength int) string {
+	b := make([]byte, g.between(minLength, maxLength))
+	for i := range b {
+		b[i] = generatorLetters[g.rand.Intn(len(generatorLetters))]
+	}
+
+	return string(b)
+}
+
+// between returns a random int in [lo, hi].
+func (g *Generator) between(lo, hi int) int {
+	if hi <= lo {
+		return lo
+	}
+
+	return lo + g.rand.Intn(hi-lo+1)
+}
+
+// patternString returns a random string matching a regular expression.
+func (g *Generator) patternString(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var b strings.Builder
+	g.writeRegexp(&b, re.Simplify())
+
+	return b.String(), nil
+}
+
+// writeRegexp writes a random string matching a parsed regular expression.
+// The anchors are ignored since the string is generated from its start to its end.
+func (g *Generator) writeRegexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(generatorLetters[g.rand.Intn(len(generatorLetters))])
+	case syntax.OpCapture:
+		g.writeRegexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writeRegexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.writeRegexp(b, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			lo, hi = 0, g.ArrayLength
+		case syntax.OpPlus:
+			lo, hi = 1, 1+g.ArrayLength
+		case syntax.OpQuest:
+			lo, hi = 0, 1
+		default:
+			if hi < 0 {
+				hi = lo + g.ArrayLength
+			}
+		}
+		for i, n := 0, g.between(lo, hi); i < n; i++ {
+			g.writeRegexp(b, re.Sub[0])
+		}
+	default:
+		// The empty matches, like the anchors and the word boundaries, write nothing.
+	}
+}
+
+// classRune returns a random rune of a character class given as range pairs, printable ASCII if possible.
+func (g *Generator) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < ' ' {
+			lo = ' '
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+	if len(ranges) < 2 {
+		return 'a'
+	}
+
+	i := 2 * g.rand.Intn(len(ranges)/2)
+
+	return ranges[i] + rune(g.rand.Intn(int(ranges[i+1]-ranges[i])+1))
+}
+
+// flattenSchema resolves the reference of a schema and merges its allOf subschemas into it.
+// The merge keeps the tightest bounds, the union of the properties and the required ones,
+// and the intersection of the types and the enums.
+func (o *OpenAPI) flattenSchema(schema JSONSchema) (JSONSchema, error) {
+	v := valueValidator{doc: o}
+
+	schema, err := v.resolve(schema)
+	if err != nil {
+		return JSONSchema{}, err
+	}
+
+	allOf := schema.AllOf
+	schema.AllOf = nil
+	for _, sub := range allOf {
+		sub, err = o.flattenSchema(sub)
+		if err != nil {
+			return JSONSchema{}, err
+		}
+		schema = mergeSchemas(schema, sub)
+	}
+
+	return schema, nil
+}
+
+// mergeSchemas returns a schema of the values valid against both schemas, as far as the keywords allow it.
+func mergeSchemas(a, b JSONSchema) JSONSchema {
+	merged := a
+
+	switch {
+	case a.Type == nil:
+		merged.Type = b.Type
+	case b.Type != nil:
+		var types []any
+		for _, t := range a.Types() {
+			if b.HasType(t) || t == "integer" && b.HasType("number") {
+				types = append(types, t)
+			} else if t == "number" && b.HasType("integer") {
+				types = append(types, "integer")
+			}
+		}
+		merged.Type = types
+	}
+
+	switch {
+	case a.Enum == nil:
+		merged.Enum = b.Enum
+	case b.Enum != nil:
+		var enum []any
+		for _, value := range a.Enum {
+			if enumContains(b.Enum, value) {
+				enum = append(enum, value)
+			}
+		}
+		merged.Enum = enum
+	}
+
+	if merged.Format == "" {
+		merged.Format = b.Format
+	}
+	if merged.Pattern == "" {
+		merged.Pattern = b.Pattern
+	}
+	switch {
+	case merged.Items == nil:
+		merged.Items = b.Items
+	case b.Items != nil:
+		merged.Items = &JSONSchema{AllOf: []JSONSchema{*a.Items, *b.Items}}
+	}
+	if merged.Not == nil {
+		merged.Not = b.Not
+	}
+	if merged.AdditionalProperties == nil {
+		merged.AdditionalProperties = b.AdditionalProperties
+	}
+	merged.AnyOf = append(append([]JSONSchema(nil), a.AnyOf...), b.AnyOf...)
+	merged.OneOf = append(append([]JSONSchema(nil), a.OneOf...), b.OneOf...)
+
+	merged.Minimum = maxBound(a.Minimum, b.Minimum)
+	merged.ExclusiveMinimum = boundValue(maxBound(a.exclusiveMinimum(), b.exclusiveMinimum()))
+	merged.ExclusiveMaximum = boundValue(minBound(a.exclusiveMaximum(), b.exclusiveMaximum()))
+	merged.Maximum = minBound(a.Maximum, b.Maximum)
+	merged.MinLength = maxInt(a.MinLength, b.MinLength)
+	merged.MinItems = maxInt(a.MinItems, b.MinItems)
+	merged.MaxLength = minLimit(a.MaxLength, b.MaxLength)
+	merged.MaxItems = minLimit(a.MaxItems, b.MaxItems)
+
+	if len(b.Properties) > 0 {
+		merged.Properties = make(map[string]JSONSchema, len(a.Properties)+len(b.Properties))
+		for name, property := range a.Properties {
+			merged.Properties[name] = property
+		}
+		for name, property := range b.Properties {
+			if existing, ok := merged.Properties[name]; ok {
+				property = JSONSchema{AllOf: []JSONSchema{existing, property}}
+			}
+			merged.Properties[name] = property
+		}
+	}
+
+	merged.Required = append([]string(nil), a.Required...)
+	for _, name := range b.Required {
+		if !containsString(merged.Required, name) {
+			merged.Required = append(merged.Required, name)
+		}
+	}
+
+	return merged
+}
+
+func maxBound(a, b *float64) *float64 {
+	if a == nil || b != nil && *b > *a {
+		return b
+	}
+
+	return a
+}
+
+// boundValue returns the value of the exclusive bound keyword holding a bound, nil if there is none.
+func boundValue(bound *float64) any {
+	if bound == nil {
+		return nil
+	}
+
+	return *bound
+}
+
+func minBound(a, b *float64) *float64 {
+	if a == nil || b != nil && *b < *a {
+		return b
+	}
+
+	return a
+}
+
+func maxInt(a, b int) int {
+	if b > a {
+		return b
+	}
+
+	return a
+}
+
+// minLimit returns the lowest limit, 0 meaning no limit.
+func minLimit(a, b int) int {
+	if a == 0 || b != 0 && b < a {
+		return b
+	}
+
+	return a
+}
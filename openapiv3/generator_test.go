@@ -0,0 +1,169 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generatorOAS() *OpenAPI {
+	return &OpenAPI{
+		Components: &Components{
+			Schemas: map[string]Schema{
+				"Pet": {JSONSchema: JSONSchema{
+					Type:     "object",
+					Required: []string{"id", "name", "kind"},
+					Properties: map[string]JSONSchema{
+						"id":        {Type: "string", Format: "uuid"},
+						"name":      {Type: "string", MinLength: 2, MaxLength: 8},
+						"kind":      {Enum: []any{"cat", "dog"}},
+						"code":      {Type: "string", Pattern: `^[A-Z]{3}-\d{2,4}(x|y)?$`},
+						"age":       {Type: "integer", Minimum: float64Ptr(1), ExclusiveMaximum: 30.0},
+						"weight":    {Type: "number", ExclusiveMinimum: 0.5, Maximum: float64Ptr(2)},
+						"born":      {Type: "string", Format: "date-time"},
+						"contact":   {Type: "string", Format: "email"},
+						"address":   {Type: "string", Format: "ipv4"},
+						"tags":      {Type: "array", MinItems: 1, MaxItems: 4, Items: &JSONSchema{Type: "string"}},
+						"nullable":  {Type: []any{"boolean", "null"}},
+						"parent":    {Ref: "#/components/schemas/Pet"},
+						"children":  {Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/Pet"}},
+						"toy":       {Ref: "#/components/schemas/Toy"},
+						"nickname":  {AllOf: []JSONSchema{{Type: "string", MinLength: 4}, {MaxLength: 4}}},
+						"vaccinate": {Type: "boolean"},
+					},
+				}},
+				"Toy": {JSONSchema: JSONSchema{
+					OneOf: []JSONSchema{
+						{Type: "object", Required: []string{"ball"}, Properties: map[string]JSONSchema{"ball": {Type: "integer", Maximum: float64Ptr(3)}}},
+						{Type: "object", Required: []string{"rope"}, Properties: map[string]JSONSchema{"rope": {Type: "number"}}},
+					},
+					Not: &JSONSchema{Required: []string{"ball", "rope"}},
+				}},
+			},
+		},
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	oas := generatorOAS()
+	schema := Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Pet"}}
+
+	for seed := int64(0); seed < 50; seed++ {
+		value, err := NewGenerator(oas, seed).Generate(schema)
+		require.NoError(t, err, "seed %d", seed)
+		assert.NoError(t, oas.ValidateValue(schema.JSONSchema, value), "seed %d", seed)
+	}
+}
+
+func TestGenerator_Generate_deterministic(t *testing.T) {
+	oas := generatorOAS()
+	schema := Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Pet"}}
+
+	g1, g2 := NewGenerator(oas, 42), NewGenerator(oas, 42)
+	for i := 0; i < 5; i++ {
+		v1, err := g1.Generate(schema)
+		require.NoError(t, err)
+		v2, err := g2.Generate(schema)
+		require.NoError(t, err)
+
+		assert.Equal(t, v1, v2)
+	}
+
+	v3, err := NewGenerator(oas, 43).Generate(schema)
+	require.NoError(t, err)
+	v1, err := NewGenerator(oas, 42).Generate(schema)
+	require.NoError(t, err)
+	assert.NotEqual(t, v1, v3)
+}
+
+func TestGenerator_Generate_values(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema JSONSchema
+		check  func(t *testing.T, value any)
+	}{
+		{
+			desc:   "pattern",
+			schema: JSONSchema{Type: "string", Pattern: `^(foo|bar)[0-9]{3}$`},
+			check: func(t *testing.T, value any) {
+				t.Helper()
+				assert.Regexp(t, `^(foo|bar)[0-9]{3}$`, value)
+			},
+		},
+		{
+			desc:   "integer bounds",
+			schema: JSONSchema{Type: "integer", ExclusiveMinimum: 4.0, Maximum: float64Ptr(5)},
+			check: func(t *testing.T, value any) {
+				t.Helper()
+				assert.Equal(t, int64(5), value)
+			},
+		},
+		{
+			desc:   "array bounds",
+			schema: JSONSchema{Type: "array", MinItems: 2, MaxItems: 2, Items: &JSONSchema{Type: "integer"}},
+			check: func(t *testing.T, value any) {
+				t.Helper()
+				assert.Len(t, value, 2)
+			},
+		},
+		{
+			desc:   "null",
+			schema: JSONSchema{Type: "null"},
+			check: func(t *testing.T, value any) {
+				t.Helper()
+				assert.Nil(t, value)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := NewGenerator(&OpenAPI{}, 1).GenerateJSONSchema(test.schema)
+			require.NoError(t, err)
+			test.check(t, value)
+		})
+	}
+}
+
+func TestGenerator_Generate_errors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		schema   JSONSchema
+		expected string
+	}{
+		{
+			desc:     "unresolved reference",
+			schema:   JSONSchema{Ref: "#/components/schemas/Unknown"},
+			expected: `generate: unresolved reference "#/components/schemas/Unknown"`,
+		},
+		{
+			desc:     "empty integer range",
+			schema:   JSONSchema{Type: "integer", Minimum: float64Ptr(1.2), Maximum: float64Ptr(1.8)},
+			expected: "generate: no value between 2 and 1",
+		},
+		{
+			desc:     "invalid pattern",
+			schema:   JSONSchema{Type: "string", Pattern: "("},
+			expected: "generate: invalid pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			desc:     "unsatisfiable schema",
+			schema:   JSONSchema{Type: "string", Not: &JSONSchema{Type: "string"}},
+			expected: "generate: no valid value after 20 attempts: value must not match the schema of not",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewGenerator(&OpenAPI{}, 1).GenerateJSONSchema(test.schema)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package openapiv3
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateGoClient returns the gofmt-ed source of a Go file of the given package declaring
+// the types of the component schemas, see GenerateGoTypes, and a client of the operations of the paths:
+//   - a Client with a method per operation, named after its operationId, taking the parameters in a struct
+//     and the request body, and returning the response decoded into the type of its status code;
+//   - a field of the Client per security scheme of the components, holding its credentials:
+//     the key of an apiKey scheme, the token of a bearer, oauth2 or openIdConnect scheme,
+//     or the BasicCredentials of a basic scheme;
+//   - ServerURL, returning the URL of one of the servers of the document with its variables replaced.
+//
+// The parameters are serialized according to their style and explode values, and the JSON bodies are encoded.
+// A request is sent with the credentials of the first security requirement of the operation
+// for which the client holds the credentials of all the schemes.
+// A response with a status code the operation does not declare is returned as a *ResponseError.
+func (o *OpenAPI) GenerateGoClient(pkg string) ([]byte, error) {
+	g := newGoTypes(o, "Client", "NewClient", "BasicCredentials", "ResponseError", "ServerURL")
+
+	credentials := g.goCredentials()
+	if err := g.declareComponents(); err != nil {
+		return nil, err
+	}
+
+	operations, err := g.goOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	g.decls = append(g.decls, goClientType(credentials), goServers(o.Servers))
+	for _, op := range operations {
+		g.decls = append(g.decls, goClientOperation(op, o.Security))
+	}
+	g.decls = append(g.decls, goClientRuntime, goParameterRuntime)
+
+	for _, path := range []string{
+		"bytes", "context", "encoding/base64", "encoding/json", "errors", "fmt", "io", "net/http", "net/url",
+		"reflect", "sort", "strconv", "strings", "time",
+	} {
+		g.imports[path] = true
+	}
+
+	return goFile(pkg, g.imports, g.body())
+}
+
+// goCredential is a security scheme with the Client field holding its credentials.
+type goCredential struct {
+	scheme string
+	SecurityScheme
+
+	field string
+	// basic reports whether the field holds BasicCredentials rather than a key or token.
+	basic bool
+}
+
+// goCredentials returns the credentials of the security schemes the client supports, sorted by scheme name.
+// The names of the fields are reserved among the operation names, which are the names of the Client methods.
+func (g *goTypes) goCredentials() []goCredential {
+	fields := goNames{"BaseURL": true, "HTTPClient": true}
+
+	var credentials []goCredential
+	for _, name := range sortedKeys(g.doc.components().SecuritySchemes) {
+		scheme := g.doc.components().SecuritySchemes[name]
+
+		credential := goCredential{scheme: name, SecurityScheme: scheme}
+		switch scheme.Type {
+		case "apiKey", "oauth2", "openIdConnect":
+		case "http":
+			credential.basic = strings.EqualFold(scheme.Scheme, "basic")
+		default:
+			// The mutualTLS credentials are set in the TLS configuration of the HTTP client.
+			continue
+		}
+
+		field := goName(name)
+		if field == "" {
+			field = "Credentials"
+		}
+		credential.field = fields.unique(field)
+		g.operationNames[credential.field] = true
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials
+}
+
+// goClientType returns the declarations of the Client with its credentials.
+func goClientType(credentials []goCredential) string {
+	var b strings.Builder
+	b.WriteString(`// Client calls the operations of the API.
+type Client struct {
+	// BaseURL is the URL of the server the requests are sent to, see ServerURL.
+	BaseURL string
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+`)
+	for i, c := range credentials {
+		if i == 0 {
+			b.WriteByte('\n')
+		}
+		switch {
+		case c.basic:
+			fmt.Fprintf(&b, "\t// %s holds the credentials of the %s security scheme.\n", c.field, c.scheme)
+			fmt.Fprintf(&b, "\t%s *BasicCredentials\n", c.field)
+		case c.Type == "apiKey":
+			fmt.Fprintf(&b, "\t// %s is the key of the %s security scheme.\n", c.field, c.scheme)
+			fmt.Fprintf(&b, "\t%s string\n", c.field)
+		default:
+			fmt.Fprintf(&b, "\t// %s is the token of the %s security scheme.\n", c.field, c.scheme)
+			fmt.Fprintf(&b, "\t%s string\n", c.field)
+		}
+	}
+	b.WriteString(`}
+
+// BasicCredentials are the credentials of the HTTP basic authentication.
+type BasicCredentials struct {
+	Username string
+	Password string
+}
+
+// NewClient returns a Client sending the requests to the given server URL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// hasCredentials reports whether the client holds the credentials of a security scheme.
+func (c *Client) hasCredentials(scheme string) bool {
+	switch scheme {
+`)
+	for _, c := range credentials {
+		fmt.Fprintf(&b, "\tcase %q:\n", c.scheme)
+		if c.basic {
+			fmt.Fprintf(&b, "\t\treturn c.%s != nil\n", c.field)
+		} else {
+			fmt.Fprintf(&b, "\t\treturn c.%s != \"\"\n", c.field)
+		}
+	}
+	b.WriteString(`	default:
+		return false
+	}
+}
+
+// applyCredentials sets the credentials of a security scheme to a request.
+func (c *Client) applyCredentials(req *http.Request, scheme string) {
+	switch scheme {
+`)
+	for _, c := range credentials {
+		fmt.Fprintf(&b, "\tcase %q:\n", c.scheme)
+		switch {
+		case c.basic:
+			fmt.Fprintf(&b, "\t\treq.SetBasicAuth(c.%[1]s.Username, c.%[1]s.Password)\n", c.field)
+		case c.Type == "apiKey" && c.In == "query":
+			fmt.Fprintf(&b, "\t\tsetQuery(req, %q, c.%s)\n", c.Name, c.field)
+		case c.Type == "apiKey" && c.In == "cookie":
+			fmt.Fprintf(&b, "\t\treq.AddCookie(&http.Cookie{Name: %q, Value: c.%s})\n", c.Name, c.field)
+		case c.Type == "apiKey":
+			fmt.Fprintf(&b, "\t\treq.Header.Set(%q, c.%s)\n", c.Name, c.field)
+		case c.Type == "http" && !strings.EqualFold(c.Scheme, "bearer"):
+			fmt.Fprintf(&b, "\t\treq.Header.Set(\"Authorization\", %q+c.%s)\n", c.Scheme+" ", c.field)
+		default:
+			fmt.Fprintf(&b, "\t\treq.Header.Set(\"Authorization\", \"Bearer \"+c.%s)\n", c.field)
+		}
+	}
+	b.WriteString("\t}\n}\n")
+
+	return b.String()
+}
+
+// goServers returns the declaration of the servers of the document.
+func goServers(servers []Server) string {
+	var b strings.Builder
+	b.WriteString("// servers lists the servers of the API.\n")
+	b.WriteString("var servers = []server{\n")
+	for _, s := range servers {
+		fmt.Fprintf(&b, "\t{\n\t\turl: %q,\n", s.URL)
+		if len(s.Variables) > 0 {
+			b.WriteString("\t\tvariables: map[string]serverVariable{\n")
+			for _, name := range sortedKeys(s.Variables) {
+				variable := s.Variables[name]
+				fmt.Fprintf(&b, "\t\t\t%q: {defaultValue: %q", name, variable.Default)
+				if len(variable.Enum) > 0 {
+					quoted := make([]string, 0, len(variable.Enum))
+					for _, e := range variable.Enum {
+						quoted = append(quoted, strconv.Quote(e))
+					}
+					fmt.Fprintf(&b, ", enum: []string{%s}", strings.Join(quoted, ", "))
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("\t\t},\n")
+		}
+		b.WriteString("\t},\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// goClientOperation returns the Client method calling an operation.
+// The security requirements of the document apply to the operations which do not define theirs.
+func goClientOperation(op goOperation, security []SecurityRequirement) string {
+	var b strings.Builder
+	b.WriteString(operationDoc(op, "calls"))
+	fmt.Fprintf(&b, "func (c *Client) %s(%s) %s {\n", op.name, goOperationArgs(op), goOperationResults(op))
+
+	failure := "nil, err"
+	if op.response == "" {
+		failure = "err"
+	}
+
+	b.WriteString("\treq, err := c.newRequest(ctx, ")
+	fmt.Fprintf(&b, "%q, %q, ", op.method, op.path)
+	if len(op.parameters) > 0 {
+		b.WriteString("[]parameterValue{\n")
+		for _, p := range op.parameters {
+			fmt.Fprintf(&b, "\t\t{%s, params.%s},\n", goParameterSpec(p), p.field)
+		}
+		b.WriteString("\t}, ")
+	} else {
+		b.WriteString("nil, ")
+	}
+	if op.body != nil {
+		fmt.Fprintf(&b, "%q, body)\n", op.body.contentType)
+	} else {
+		b.WriteString(`"", nil)` + "\n")
+	}
+	fmt.Fprintf(&b, "\tif err != nil {\n\t\treturn %s\n\t}\n\n", failure)
+
+	if op.operation.Security != nil {
+		security = op.operation.Security
+	}
+	fmt.Fprintf(&b, "\tresp, err := c.do(req, %s)\n", goSecurityLiteral(security))
+	fmt.Fprintf(&b, "\tif err != nil {\n\t\treturn %s\n\t}\n", failure)
+	b.WriteString("\tdefer resp.Body.Close()\n\n")
+
+	if op.response == "" {
+		b.WriteString("\tif resp.StatusCode/100 != 2 {\n\t\treturn newResponseError(resp)\n\t}\n\n\treturn nil\n}\n")
+		return b.String()
+	}
+
+	// The exact status codes are matched first, then the ranges, then the default response.
+	responses := append([]goResponse(nil), op.responses...)
+	rank := func(r goResponse) int {
+		switch {
+		case r.status() != 0:
+			return 0
+		case r.code != "default":
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(responses, func(i, j int) bool { return rank(responses[i]) < rank(responses[j]) })
+
+	b.WriteString("\tswitch {\n")
+	hasDefault := false
+	for _, r := range responses {
+		switch rank(r) {
+		case 0:
+			fmt.Fprintf(&b, "\tcase resp.StatusCode == %d:\n", r.status())
+		case 1:
+			fmt.Fprintf(&b, "\tcase resp.StatusCode/100 == %s:\n", r.code[:1])
+		default:
+			b.WriteString("\tdefault:\n")
+			hasDefault = true
+		}
+
+		if r.status() != 0 && len(r.headers) == 0 && r.body == nil {
+			fmt.Fprintf(&b, "\t\treturn %s{}, nil\n", r.typeName)
+			continue
+		}
+
+		if r.status() == 0 {
+			fmt.Fprintf(&b, "\t\tresponse := %s{StatusCode: resp.StatusCode}\n", r.typeName)
+		} else {
+			fmt.Fprintf(&b, "\t\tvar response %s\n", r.typeName)
+		}
+		if len(r.headers) > 0 {
+			b.WriteString("\t\tif err := decodeHeaders(resp.Header, []parameterValue{\n")
+			for _, h := range r.headers {
+				fmt.Fprintf(&b, "\t\t\t{%s, &response.%s},\n", goParameterSpec(h), h.field)
+			}
+			b.WriteString("\t\t}); err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+		}
+		if r.body != nil {
+			b.WriteString("\t\tif err := decodeResponseBody(resp, &response.Body); err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+		}
+		b.WriteString("\t\treturn response, nil\n")
+	}
+	if !hasDefault {
+		b.WriteString("\tdefault:\n\t\treturn nil, newResponseError(resp)\n")
+	}
+	b.WriteString("\t}\n}\n")
+
+	return b.String()
+}
+
+// goSecurityLiteral returns the literal of the scheme names of security requirements.
+func goSecurityLiteral(security []SecurityRequirement) string {
+	if len(security) == 0 {
+		return "nil"
+	}
+
+	requirements := make([]string, 0, len(security))
+	for _, requirement := range security {
+		schemes := make([]string, 0, len(requirement))
+		for _, scheme := range sortedKeys(requirement) {
+			schemes = append(schemes, strconv.Quote(scheme))
+		}
+		requirements = append(requirements, "{"+strings.Join(schemes, ", ")+"}")
+	}
+
+	return "[][]string{" + strings.Join(requirements, ", ") + "}"
+}
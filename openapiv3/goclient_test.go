@@ -0,0 +1,96 @@
+package openapiv3
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_GenerateGoClient(t *testing.T) {
+	oas, err := FromFile("testdata/gooperations.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoClient("petsclient")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/goclient.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+
+	typeCheck(t, src)
+}
+
+func TestOpenAPI_GenerateGoClient_requests(t *testing.T) {
+	oas, err := FromFile("testdata/gooperations.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoClient("main")
+	require.NoError(t, err)
+
+	main, err := os.ReadFile("testdata/goclient_main.go")
+	require.NoError(t, err)
+
+	out := runGo(t, map[string]string{"client.go": string(src), "main.go": string(main)})
+
+	expected := []string{
+		// The first security requirement the client holds the credentials of applies.
+		"GET /pets?filter%5BminAge%5D=2&filter%5Bname%5D=rex&limit=10&status=sold&tags=a&tags=b+c " +
+			"Authorization=Bearer token X-Request-Id=id ListPets200Response{XNext:/pets?page=2 Body:[]}",
+		"GET /pets X-Api-Key=key X-Request-Id=id ListPets200Response{XNext:/pets?page=2 Body:[]}",
+		"GET /pets X-Request-Id=id ListPets200Response{XNext:/pets?page=2 Body:[]}",
+		"GET /pets/mine?session_key=session Authorization=Basic dXNlcjpwYXNz Cookie=session=abc ListMyPets200Response{Body:[]}",
+		// The operation security overrides the document one, the empty requirement sending no credentials.
+		"GET /pets/mine Cookie=session=abc ListMyPets200Response{Body:[]}",
+		"GET /pets/42 Authorization=Bearer token ShowPetByID200Response{Body:{ID:42 Name:Rex Status:<nil>}}",
+		"GET /pets/404 ShowPetByID404Response{Body:{Code:404 Message:not found}}",
+		"GET /colors/;colors=red;colors=blue?point=x%2C1%2Cy%2C2 GetColors200Response{Body:colors}",
+		"GET /sizes/.1,2 GetSizes204Response{}",
+	}
+	assert.Equal(t, expected, strings.Split(strings.TrimSpace(out), "\n"))
+}
+
+func TestOpenAPI_GenerateGoClient_names(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/clients": PathItem{Get: &Operation{OperationID: "apiKey"}},
+		},
+		Components: &Components{
+			Schemas: map[string]Schema{
+				"Client":        {JSONSchema: JSONSchema{Type: "string"}},
+				"ResponseError": {JSONSchema: JSONSchema{Type: "string"}},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"api_key": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				"baseURL": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	src, err := oas.GenerateGoClient("clients")
+	require.NoError(t, err)
+
+	code := string(src)
+	assert.Contains(t, code, "type Client2 string")
+	assert.Contains(t, code, "type ResponseError2 string")
+	assert.Contains(t, code, "\tAPIKey string\n")
+	assert.Contains(t, code, "\tBaseURL2 string\n")
+	assert.Contains(t, code, "func (c *Client) APIKey2(ctx context.Context) error {")
+
+	typeCheck(t, src)
+}
+
+func TestOpenAPI_GenerateGoClient_errors(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/pets": PathItem{Get: &Operation{
+				Parameters: []Parameter{{Reference: Reference{Ref: "#/components/parameters/Limit"}}},
+			}},
+		},
+	}
+
+	_, err := oas.GenerateGoClient("pets")
+	assert.EqualError(t, err, `GET "/pets": unresolved reference "#/components/parameters/Limit"`)
+}
@@ -0,0 +1,195 @@
+package openapiv3
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// goInitialisms lists the words written in upper case in the Go identifiers, as golint does.
+var goInitialisms = map[string]bool{
+	"acl": true, "api": true, "ascii": true, "cpu": true, "css": true, "dns": true, "eof": true, "guid": true,
+	"html": true, "http": true, "https": true, "id": true, "ip": true, "json": true, "lhs": true, "qps": true,
+	"ram": true, "rhs": true, "rpc": true, "sla": true, "smtp": true, "sql": true, "ssh": true, "tcp": true,
+	"tls": true, "ttl": true, "udp": true, "ui": true, "uid": true, "uri": true, "url": true, "utf8": true,
+	"uuid": true, "vm": true, "xml": true, "xmpp": true, "xsrf": true, "xss": true,
+}
+
+// goName returns the exported Go identifier of a name, like PetID for pet_id or petId.
+// The name is split into words on the non-alphanumeric characters and on the case changes.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		lower := strings.ToLower(word)
+		if goInitialisms[lower] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+
+	s := b.String()
+	if s == "" {
+		return ""
+	}
+	if !unicode.IsLetter([]rune(s)[0]) {
+		// An identifier cannot start with a digit.
+		s = "N" + s
+	}
+
+	return s
+}
+
+// goUnexportedName returns the unexported Go identifier of a name, like petID for pet_id.
+func goUnexportedName(name string) string {
+	s := goName(name)
+	if s == "" {
+		return ""
+	}
+
+	words := splitWords(s)
+	if goInitialisms[strings.ToLower(words[0])] {
+		s = strings.ToLower(words[0]) + s[len(words[0]):]
+	} else {
+		runes := []rune(s)
+		runes[0] = unicode.ToLower(runes[0])
+		s = string(runes)
+	}
+
+	if isGoKeyword(s) {
+		s += "_"
+	}
+
+	return s
+}
+
+// splitWords splits a name into words on the non-alphanumeric characters and on the case changes:
+// petId, pet_id and PetID all give the words pet and Id or ID.
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// A new word starts on a lower to upper change, or on the last upper of an upper case sequence (HTTPServer).
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+func isGoKeyword(s string) bool {
+	switch s {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+		"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var":
+		return true
+	default:
+		return false
+	}
+}
+
+// goNames allocates unique Go identifiers.
+type goNames map[string]bool
+
+// unique returns the name, suffixed by a number if it is already used, and marks it as used.
+func (n goNames) unique(name string) string {
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	n[candidate] = true
+
+	return candidate
+}
+
+// goComment returns a text as a Go comment, prefixed by the given name if the text does not start with it.
+func goComment(name, text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	if !strings.HasPrefix(text, name+" ") {
+		text = name + " is " + lowerFirst(text)
+	}
+
+	return goCommentLines(text)
+}
+
+// goCommentLines returns a text as Go comment lines.
+func goCommentLines(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight("// "+strings.TrimSpace(line), " "))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 1 && unicode.IsUpper(runes[1]) {
+		// An acronym keeps its case.
+		return s
+	}
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
+
+// goFile returns the gofmt-ed source of a Go file.
+func goFile(pkg string, imports map[string]bool, body string) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("// Code generated by openapi-go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		b.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	b.WriteString(body)
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("format source: %w", err)
+	}
+
+	return src, nil
+}
@@ -0,0 +1,341 @@
+package openapiv3
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// goOperation is an operation with the Go names and types of its parameters, body and responses.
+type goOperation struct {
+	// name is the Go method name of the operation, after its operationId or its method and path.
+	name      string
+	method    string
+	path      string
+	operation *Operation
+	// params is the name of the parameters struct, empty if the operation has no parameter.
+	params     string
+	parameters []goParameter
+	body       *goBody
+	// response is the name of the interface of the responses, empty if the operation has no response.
+	response  string
+	responses []goResponse
+}
+
+// goParameter is a parameter with the Go name and type of its field.
+type goParameter struct {
+	Parameter
+
+	field    string
+	typ      string
+	required bool
+	style    string
+	// explode is the explode value, defaulted according to the style.
+	explode bool
+	// contentType is the media type of a parameter serialized by its content instead of its style.
+	contentType string
+	// constraints is the literal of the constraints of the schema checked by a server, empty if it has none.
+	constraints string
+}
+
+// goBody is a request or response body with its media type and Go type,
+// []byte for a media type which is not JSON.
+type goBody struct {
+	contentType string
+	typ         string
+	required    bool
+}
+
+// goResponse is a response of an operation with the Go name of its type.
+type goResponse struct {
+	// code is the status code, the range of status codes, e.g. 2XX, or default.
+	code        string
+	typeName    string
+	description string
+	headers     []goParameter
+	body        *goBody
+}
+
+// status returns the status code of the response, 0 for the default response and the ranges.
+func (r goResponse) status() int {
+	code, err := strconv.Atoi(r.code)
+	if err != nil {
+		return 0
+	}
+
+	return code
+}
+
+// fallbackStatus returns the status code of a default or range response when none is given:
+// the first code of the range, 200 for the default response.
+func (r goResponse) fallbackStatus() int {
+	if code, err := strconv.Atoi(strings.ReplaceAll(strings.ToUpper(r.code), "X", "0")); err == nil {
+		return code
+	}
+
+	return 200
+}
+
+// goOperations returns the Go model of the operations of the paths, sorted by path then in the order of the methods.
+// The types of the parameters, bodies and responses are declared along.
+func (g *goTypes) goOperations() ([]goOperation, error) {
+	var operations []goOperation
+	for _, path := range sortedKeys(g.doc.Paths) {
+		pathItem, err := g.doc.ResolvePathItem(g.doc.Paths[path])
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", path, err)
+		}
+
+		for _, mo := range pathItem.Operations() {
+			ep, err := g.doc.EffectiveParameters(path, mo.Method)
+			if err != nil {
+				return nil, err
+			}
+
+			slot := len(g.decls)
+			g.decls = append(g.decls, "")
+
+			op, err := g.goOperation(path, mo, ep)
+			if err != nil {
+				return nil, fmt.Errorf("%s %q: %w", mo.Method, path, err)
+			}
+			g.decls[slot] = operationTypes(op)
+			operations = append(operations, op)
+		}
+	}
+
+	return operations, nil
+}
+
+func (g *goTypes) goOperation(path string, mo MethodOperation, ep *EffectiveParameters) (goOperation, error) {
+	name := goName(mo.Operation.OperationID)
+	if name == "" {
+		name = goName(strings.ToLower(mo.Method) + " " + path)
+	}
+
+	op := goOperation{
+		name:      g.operationNames.unique(name),
+		method:    mo.Method,
+		path:      path,
+		operation: mo.Operation,
+	}
+
+	fields := goNames{}
+	for _, p := range ep.All() {
+		parameter, err := g.goParameter(p, op.name, fields)
+		if err != nil {
+			return goOperation{}, fmt.Errorf("%s parameter %q: %w", p.In, p.Name, err)
+		}
+		op.parameters = append(op.parameters, parameter)
+	}
+	if len(op.parameters) > 0 {
+		op.params = g.names.unique(op.name + "Params")
+	}
+
+	if mo.Operation.RequestBody != nil {
+		requestBody, err := g.doc.ResolveRequestBody(*mo.Operation.RequestBody)
+		if err != nil {
+			return goOperation{}, fmt.Errorf("request body: %w", err)
+		}
+
+		if op.body, err = g.goBody(requestBody.Content, op.name+"RequestBody"); err != nil {
+			return goOperation{}, fmt.Errorf("request body: %w", err)
+		}
+		if op.body != nil {
+			op.body.required = requestBody.Required
+		}
+	}
+
+	if mo.Operation.Responses != nil && len(*mo.Operation.Responses) > 0 {
+		op.response = g.names.unique(op.name + "Response")
+		for _, code := range sortedKeys(*mo.Operation.Responses) {
+			response, err := g.goResponse(op.name, code, (*mo.Operation.Responses)[code])
+			if err != nil {
+				return goOperation{}, fmt.Errorf("response %q: %w", code, err)
+			}
+			op.responses = append(op.responses, response)
+		}
+	}
+
+	return op, nil
+}
+
+// goParameter returns the Go model of a parameter or a header, the field name being unique among the fields.
+func (g *goTypes) goParameter(p Parameter, context string, fields goNames) (goParameter, error) {
+	field := goName(p.Name)
+	if field == "" {
+		field = "Param"
+	}
+	field = fields.unique(field)
+
+	parameter := goParameter{Parameter: p, field: field, required: p.In == "path" || p.Required != nil && *p.Required}
+	parameter.style, parameter.explode = p.SerializationStyle()
+
+	schema := JSONSchema{Type: "string"}
+	switch {
+	case p.Schema != nil:
+		schema = p.Schema.JSONSchema
+	case len(p.Content) > 0:
+		parameter.contentType = sortedKeys(p.Content)[0]
+		if s := p.Content[parameter.contentType].Schema; s != nil {
+			schema = s.JSONSchema
+		} else {
+			schema = JSONSchema{}
+		}
+	}
+
+	typ, err := g.goType(schema, context+field)
+	if err != nil {
+		return goParameter{}, err
+	}
+	if !parameter.required && !isNillableGoType(typ) {
+		typ = "*" + typ
+	}
+	parameter.typ = typ
+
+	return parameter, nil
+}
+
+// goBody returns the Go model of the preferred media type of a content: a JSON one, or else the first one.
+// It returns nil for an empty content.
+func (g *goTypes) goBody(content map[string]MediaType, context string) (*goBody, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	contentType := sortedKeys(content)[0]
+	for _, key := range sortedKeys(content) {
+		if isJSONMediaType(key) {
+			contentType = key
+			break
+		}
+	}
+
+	body := &goBody{contentType: contentType, typ: "[]byte"}
+	if schema := content[contentType].Schema; isJSONMediaType(contentType) && schema != nil {
+		typ, err := g.goType(schema.JSONSchema, context)
+		if err != nil {
+			return nil, err
+		}
+		body.typ = typ
+	} else if isJSONMediaType(contentType) {
+		body.typ = "any"
+	}
+
+	return body, nil
+}
+
+func (g *goTypes) goResponse(operation, code string, response Response) (goResponse, error) {
+	response, err := g.doc.ResolveResponse(response)
+	if err != nil {
+		return goResponse{}, err
+	}
+
+	suffix := strings.ToUpper(code)
+	if code == "default" {
+		suffix = "Default"
+	}
+	typeName := g.names.unique(operation + suffix + "Response")
+
+	r := goResponse{code: code, typeName: typeName, description: response.Description}
+
+	fields := goNames{"Body": true, "StatusCode": true}
+	for _, name := range sortedKeys(response.Headers) {
+		if textproto.CanonicalMIMEHeaderKey(name) == "Content-Type" {
+			continue
+		}
+
+		header, err := g.doc.ResolveHeader(response.Headers[name])
+		if err != nil {
+			return goResponse{}, fmt.Errorf("header %q: %w", name, err)
+		}
+
+		p := header.Parameter
+		p.Name, p.In = name, "header"
+		parameter, err := g.goParameter(p, typeName, fields)
+		if err != nil {
+			return goResponse{}, fmt.Errorf("header %q: %w", name, err)
+		}
+		r.headers = append(r.headers, parameter)
+	}
+
+	if r.body, err = g.goBody(response.Content, typeName+"Body"); err != nil {
+		return goResponse{}, err
+	}
+
+	return r, nil
+}
+
+// operationTypes returns the declarations of the parameters struct and of the response types of an operation.
+func operationTypes(op goOperation) string {
+	var b strings.Builder
+	if op.params != "" {
+		fmt.Fprintf(&b, "// %s holds the parameters of %s.\n", op.params, op.name)
+		fmt.Fprintf(&b, "type %s struct {\n", op.params)
+		for _, p := range op.parameters {
+			b.WriteString(goComment(p.field, p.Description))
+			fmt.Fprintf(&b, "\t%s %s\n", p.field, p.typ)
+		}
+		b.WriteString("}\n")
+	}
+
+	if op.response == "" {
+		return b.String()
+	}
+	if b.Len() > 0 {
+		b.WriteByte('\n')
+	}
+
+	fmt.Fprintf(&b, "// %s is implemented by the responses of %s.\n", op.response, op.name)
+	fmt.Fprintf(&b, "type %[1]s interface {\n\tis%[1]s()\n}\n", op.response)
+	for _, r := range op.responses {
+		fmt.Fprintf(&b, "\n// %s is the %s response of %s.\n", r.typeName, r.code, op.name)
+		if description := strings.TrimSpace(r.description); description != "" {
+			b.WriteString("//\n")
+			b.WriteString(goCommentLines(description))
+		}
+
+		if r.status() != 0 && len(r.headers) == 0 && r.body == nil {
+			fmt.Fprintf(&b, "type %s struct{}\n\n", r.typeName)
+			fmt.Fprintf(&b, "func (%s) is%s() {}\n", r.typeName, op.response)
+			continue
+		}
+
+		fmt.Fprintf(&b, "type %s struct {\n", r.typeName)
+		if r.status() == 0 {
+			fmt.Fprintf(&b, "\t// StatusCode is the status code of the response, %d if not set.\n", r.fallbackStatus())
+			b.WriteString("\tStatusCode int\n")
+		}
+		for _, h := range r.headers {
+			b.WriteString(goComment(h.field, h.Description))
+			fmt.Fprintf(&b, "\t%s %s\n", h.field, h.typ)
+		}
+		if r.body != nil {
+			fmt.Fprintf(&b, "\tBody %s\n", r.body.typ)
+		}
+		b.WriteString("}\n\n")
+		fmt.Fprintf(&b, "func (%s) is%s() {}\n", r.typeName, op.response)
+	}
+
+	return b.String()
+}
+
+// operationDoc returns the Go comment of the method of an operation.
+func operationDoc(op goOperation, verb string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s %s %s %s.\n", op.name, verb, op.method, op.path)
+
+	for _, text := range []string{op.operation.Summary, op.operation.Description} {
+		if text = strings.TrimSpace(text); text != "" {
+			b.WriteString("//\n")
+			b.WriteString(goCommentLines(text))
+		}
+	}
+
+	if op.operation.Deprecated {
+		b.WriteString("//\n// Deprecated: the operation is deprecated.\n")
+	}
+
+	return b.String()
+}
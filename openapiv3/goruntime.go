@@ -0,0 +1,1077 @@
+package openapiv3
+
+// goParameterRuntime holds the declarations the generated servers and clients rely on
+// to serialize and deserialize the parameters according to their style.
+const goParameterRuntime = `// parameter describes the serialization of a parameter.
+type parameter struct {
+	name     string
+	in       string
+	style    string
+	explode  bool
+	required bool
+	// json reports whether the parameter is serialized as JSON rather than according to its style.
+	json bool
+}
+
+// parameterValue is a parameter with its Go value, or a pointer to it to be decoded.
+type parameterValue struct {
+	parameter
+	value any
+}
+
+// splitValue splits the serialized value of a path, header or cookie parameter according to its style:
+// it returns the value of a primitive, the items of an array, or the alternated keys and values of an object.
+func splitValue(p parameter, kind, raw string) ([]string, error) {
+	sep := ","
+	switch p.style {
+	case "label":
+		rest, ok := strings.CutPrefix(raw, ".")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a label value", raw)
+		}
+		raw = rest
+		if p.explode {
+			sep = "."
+		}
+	case "matrix":
+		rest, ok := strings.CutPrefix(raw, ";")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a matrix value", raw)
+		}
+		if p.explode && kind == "object" {
+			return splitPairs(strings.Split(rest, ";"))
+		}
+		if p.explode && kind == "array" {
+			items := strings.Split(rest, ";")
+			for i, item := range items {
+				if items[i], ok = strings.CutPrefix(item, p.name+"="); !ok {
+					return nil, fmt.Errorf("%q is not a value of %s", item, p.name)
+				}
+			}
+			return items, nil
+		}
+		if raw, ok = strings.CutPrefix(rest, p.name+"="); !ok {
+			return nil, fmt.Errorf("%q is not a value of %s", rest, p.name)
+		}
+	}
+
+	switch {
+	case kind == "primitive":
+		return []string{raw}, nil
+	case raw == "":
+		return nil, nil
+	case kind == "object" && p.explode:
+		return splitPairs(strings.Split(raw, sep))
+	default:
+		return strings.Split(raw, sep), nil
+	}
+}
+
+// splitPairs splits key=value pairs into alternated keys and values.
+func splitPairs(pairs []string) ([]string, error) {
+	values := make([]string, 0, 2*len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a key=value pair", pair)
+		}
+		values = append(values, key, value)
+	}
+
+	return values, nil
+}
+
+// structFields returns the indexes of the fields of a struct by their JSON name.
+func structFields(t reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields[name] = field.Index
+	}
+
+	return fields
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// valueKind returns how a Go type is serialized in a parameter: as a primitive, an array or an object.
+func valueKind(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return "primitive"
+	case t.Kind() == reflect.Slice:
+		return "array"
+	case t.Kind() == reflect.Struct || t.Kind() == reflect.Map:
+		return "object"
+	default:
+		return "primitive"
+	}
+}
+
+// setValue sets a value from the values returned by splitValue.
+// The unknown properties of an object are ignored.
+func setValue(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), values); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch valueKind(v.Type()) {
+	case "array":
+		items := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, s := range values {
+			if err := parseValue(items.Index(i), s); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		v.Set(items)
+	case "object":
+		if len(values)%2 != 0 {
+			return errors.New("a property has no value")
+		}
+
+		if v.Kind() == reflect.Map {
+			m := reflect.MakeMapWithSize(v.Type(), len(values)/2)
+			for i := 0; i < len(values); i += 2 {
+				item := reflect.New(v.Type().Elem()).Elem()
+				if err := parseValue(item, values[i+1]); err != nil {
+					return fmt.Errorf("property %q: %w", values[i], err)
+				}
+				m.SetMapIndex(reflect.ValueOf(values[i]).Convert(v.Type().Key()), item)
+			}
+			v.Set(m)
+			return nil
+		}
+
+		fields := structFields(v.Type())
+		for i := 0; i < len(values); i += 2 {
+			index, ok := fields[values[i]]
+			if !ok {
+				continue
+			}
+			if err := parseValue(v.FieldByIndex(index), values[i+1]); err != nil {
+				return fmt.Errorf("property %q: %w", values[i], err)
+			}
+		}
+	default:
+		if len(values) == 0 {
+			return errors.New("missing value")
+		}
+		return parseValue(v, values[0])
+	}
+
+	return nil
+}
+
+// parseValue sets a primitive value from its string representation.
+func parseValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := parseValue(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("%q is not a date-time", s)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case bytesType:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("%q is not base64 encoded", s)
+		}
+		v.SetBytes(b)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		v.SetBytes([]byte(s))
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(s))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// serializeValue returns the serialized items of a value: the value of a primitive, the items of an array,
+// or the alternated keys and values of an object sorted by key, along with the kind of the value.
+// It reports false for a nil value.
+func serializeValue(value any) (string, []string, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil, false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", nil, false
+	}
+
+	kind := valueKind(v.Type())
+	switch kind {
+	case "array":
+		if v.IsNil() {
+			return "", nil, false
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatPrimitive(v.Index(i))
+		}
+		return kind, items, true
+	case "object":
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return "", nil, false
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var properties map[string]any
+		if err = decoder.Decode(&properties); err != nil || properties == nil {
+			return "", nil, false
+		}
+		pairs := make([]string, 0, 2*len(properties))
+		for _, key := range sortedKeys(properties) {
+			pairs = append(pairs, key, formatPrimitive(reflect.ValueOf(properties[key])))
+		}
+		return kind, pairs, true
+	default:
+		return kind, []string{formatPrimitive(v)}, true
+	}
+}
+
+// formatValue serializes the value of a path, header or cookie parameter according to its style,
+// escaping the items with the escape function if any. It reports false for a nil value.
+func formatValue(p parameter, value any, escape func(string) string) (string, bool, error) {
+	kind, items, ok := serializeValue(value)
+	if !ok {
+		return "", false, nil
+	}
+
+	if p.json {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", false, err
+		}
+		items = []string{string(data)}
+	}
+	if escape != nil {
+		for i, item := range items {
+			items[i] = escape(item)
+		}
+	}
+	if p.json {
+		return items[0], true, nil
+	}
+
+	sep, prefix := ",", ""
+	switch p.style {
+	case "label":
+		prefix = "."
+		if p.explode {
+			sep = "."
+		}
+	case "matrix":
+		prefix = ";" + p.name + "="
+		switch {
+		case p.explode && kind == "array":
+			sep = prefix
+		case p.explode && kind == "object":
+			prefix, sep = ";", ";"
+		}
+	}
+
+	if kind == "object" && p.explode {
+		pairs := make([]string, 0, len(items)/2)
+		for i := 0; i < len(items); i += 2 {
+			pairs = append(pairs, items[i]+"="+items[i+1])
+		}
+		items = pairs
+	}
+
+	return prefix + strings.Join(items, sep), true, nil
+}
+
+// formatPrimitive returns the string representation of a primitive value.
+func formatPrimitive(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	case bytesType:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+`
+
+// goServerRuntime holds the declarations the generated servers rely on.
+const goServerRuntime = `// ServeHTTP routes a request to the operation matching its method and path.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+
+	var allowed []string
+	for _, route := range routes {
+		matches := route.pattern.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+		if route.method != r.Method {
+			allowed = append(allowed, route.method)
+			continue
+		}
+
+		// The path parameters are kept escaped, to be unescaped once split according to their style.
+		pathParams := make(map[string]string, len(route.params))
+		for i, name := range route.params {
+			pathParams[name] = matches[i+1]
+		}
+
+		route.handle(h, w, r, pathParams)
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+// route is the path pattern of an operation with its handling method.
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	params  []string
+	handle  func(h *handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string)
+}
+
+// requestError is an invalid request, answered with its status code.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// fail answers a request with the status code of a requestError, or with a 500 status for the other errors.
+func fail(w http.ResponseWriter, err error) {
+	var re *requestError
+	if errors.As(err, &re) {
+		http.Error(w, re.Error(), re.status)
+		return
+	}
+
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// parameterCheck is a decoded parameter value with the constraints of its schema.
+type parameterCheck struct {
+	in          string
+	name        string
+	value       any
+	constraints *constraints
+}
+
+// checkParameters checks the decoded values of the parameters against the constraints of their schemas.
+func checkParameters(checks []parameterCheck) error {
+	for _, c := range checks {
+		if err := c.constraints.check(reflect.ValueOf(c.value)); err != nil {
+			return &requestError{status: http.StatusBadRequest, err: fmt.Errorf("%s parameter %q: %w", c.in, c.name, err)}
+		}
+	}
+
+	return nil
+}
+
+// constraints are the constraints of the schema of a parameter, the zero values constraining nothing.
+type constraints struct {
+	// enum holds the JSON encodings of the allowed values.
+	enum             []string
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	minLength        int
+	maxLength        int
+	pattern          *regexp.Regexp
+	minItems         int
+	maxItems         int
+	items            *constraints
+}
+
+// bound returns a pointer to the bound of a constraint.
+func bound(f float64) *float64 {
+	return &f
+}
+
+// check checks a value against the constraints, an absent value being valid.
+// The lengths and the pattern apply to the strings, the bounds to the numbers,
+// and the items constraints to the items of the arrays.
+func (c *constraints) check(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	if len(c.enum) > 0 {
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		allowed := false
+		for _, value := range c.enum {
+			allowed = allowed || value == string(data)
+		}
+		if !allowed {
+			return fmt.Errorf("%s is not one of %s", data, strings.Join(c.enum, ", "))
+		}
+	}
+
+	if valueKind(v.Type()) == "array" {
+		switch {
+		case c.minItems > 0 && v.Len() < c.minItems:
+			return fmt.Errorf("%d items are less than %d", v.Len(), c.minItems)
+		case c.maxItems > 0 && v.Len() > c.maxItems:
+			return fmt.Errorf("%d items are more than %d", v.Len(), c.maxItems)
+		}
+		if c.items != nil {
+			for i := 0; i < v.Len(); i++ {
+				if err := c.items.check(v.Index(i)); err != nil {
+					return fmt.Errorf("item %d: %w", i, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		switch n := utf8.RuneCountInString(s); {
+		case c.minLength > 0 && n < c.minLength:
+			return fmt.Errorf("%q is shorter than %d characters", s, c.minLength)
+		case c.maxLength > 0 && n > c.maxLength:
+			return fmt.Errorf("%q is longer than %d characters", s, c.maxLength)
+		case c.pattern != nil && !c.pattern.MatchString(s):
+			return fmt.Errorf("%q does not match %s", s, c.pattern)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.checkNumber(float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return c.checkNumber(float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return c.checkNumber(v.Float())
+	}
+
+	return nil
+}
+
+func (c *constraints) checkNumber(f float64) error {
+	switch {
+	case c.minimum != nil && f < *c.minimum:
+		return fmt.Errorf("%v is less than %v", f, *c.minimum)
+	case c.maximum != nil && f > *c.maximum:
+		return fmt.Errorf("%v is greater than %v", f, *c.maximum)
+	case c.exclusiveMinimum != nil && f <= *c.exclusiveMinimum:
+		return fmt.Errorf("%v is less than or equal to %v", f, *c.exclusiveMinimum)
+	case c.exclusiveMaximum != nil && f >= *c.exclusiveMaximum:
+		return fmt.Errorf("%v is greater than or equal to %v", f, *c.exclusiveMaximum)
+	}
+
+	return nil
+}
+
+// decodeParameters decodes the values of the parameters of a request into the pointers of the parameter values.
+// An absent optional parameter leaves its value unchanged.
+func decodeParameters(r *http.Request, pathParams map[string]string, params []parameterValue) error {
+	query := r.URL.Query()
+	for _, p := range params {
+		if err := decodeParameter(r, query, pathParams, p.parameter, p.value); err != nil {
+			return &requestError{status: http.StatusBadRequest, err: fmt.Errorf("%s parameter %q: %w", p.in, p.name, err)}
+		}
+	}
+
+	return nil
+}
+
+func decodeParameter(r *http.Request, query url.Values, pathParams map[string]string, p parameter, v any) error {
+	target := reflect.ValueOf(v).Elem()
+
+	kind := valueKind(target.Type())
+	if p.json {
+		kind = "primitive"
+	}
+
+	var (
+		values []string
+		ok     bool
+		err    error
+	)
+	switch p.in {
+	case "path":
+		var raw string
+		if raw, ok = pathParams[p.name]; ok {
+			if values, err = splitValue(p, kind, raw); err == nil {
+				values, err = unescapeValues(values)
+			}
+		}
+	case "query":
+		values, ok, err = queryValues(query, p, kind, target.Type())
+	case "header":
+		if raw := r.Header.Values(p.name); len(raw) > 0 {
+			ok = true
+			values, err = splitValue(p, kind, strings.Join(raw, ","))
+		}
+	case "cookie":
+		if cookie, cookieErr := r.Cookie(p.name); cookieErr == nil {
+			ok = true
+			// The exploded form of the cookies is not defined: they are decoded as the non exploded one.
+			p.explode = false
+			values, err = splitValue(p, kind, cookie.Value)
+		}
+	default:
+		return fmt.Errorf("unsupported location %q", p.in)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if p.required {
+			return errors.New("missing required parameter")
+		}
+		return nil
+	}
+
+	if p.json {
+		return json.Unmarshal([]byte(values[0]), v)
+	}
+
+	return setValue(target, values)
+}
+
+// unescapeValues unescapes the split values of a path parameter.
+func unescapeValues(values []string) ([]string, error) {
+	for i, value := range values {
+		unescaped, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = unescaped
+	}
+
+	return values, nil
+}
+
+// queryValues returns the values of a query parameter according to its style, as splitValue does.
+// The properties of an exploded form object are the query parameters named after the fields of its type,
+// or all of them for a map.
+func queryValues(query url.Values, p parameter, kind string, t reflect.Type) ([]string, bool, error) {
+	if kind == "primitive" {
+		values, ok := query[p.name]
+		if !ok || len(values) == 0 {
+			return nil, false, nil
+		}
+		return values[:1], true, nil
+	}
+
+	switch {
+	case p.style == "deepObject":
+		var values []string
+		for _, key := range sortedKeys(query) {
+			if name, ok := strings.CutPrefix(key, p.name+"["); ok && strings.HasSuffix(name, "]") {
+				values = append(values, strings.TrimSuffix(name, "]"), query.Get(key))
+			}
+		}
+		return values, len(values) > 0, nil
+	case kind == "object" && p.explode:
+		var values []string
+		for _, key := range objectKeys(t, query) {
+			if _, ok := query[key]; ok {
+				values = append(values, key, query.Get(key))
+			}
+		}
+		return values, len(values) > 0, nil
+	case kind == "array" && p.explode:
+		values, ok := query[p.name]
+		return values, ok, nil
+	}
+
+	values, ok := query[p.name]
+	if !ok || len(values) == 0 {
+		return nil, false, nil
+	}
+
+	sep := ","
+	switch p.style {
+	case "spaceDelimited":
+		sep = " "
+	case "pipeDelimited":
+		sep = "|"
+	}
+	if values[0] == "" {
+		return nil, true, nil
+	}
+
+	return strings.Split(values[0], sep), true, nil
+}
+
+// objectKeys returns the property names of an object type: the JSON names of the fields of a struct,
+// or the keys of the query for a map.
+func objectKeys(t reflect.Type, query url.Values) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Map {
+		return sortedKeys(query)
+	}
+
+	return sortedKeys(structFields(t))
+}
+
+// decodeBody decodes the request body into v: as JSON for a JSON media type, as is into a []byte otherwise.
+// An absent optional body leaves v unchanged.
+func decodeBody(r *http.Request, contentType string, required bool, v any) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return &requestError{status: http.StatusBadRequest, err: fmt.Errorf("read request body: %w", err)}
+	}
+
+	if len(data) == 0 {
+		if required {
+			return &requestError{status: http.StatusBadRequest, err: errors.New("missing required request body")}
+		}
+		return nil
+	}
+
+	if !mediaTypeMatches(contentType, r.Header.Get("Content-Type")) {
+		return &requestError{
+			status: http.StatusUnsupportedMediaType,
+			err:    fmt.Errorf("unsupported content type %q", r.Header.Get("Content-Type")),
+		}
+	}
+
+	if raw, ok := v.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return &requestError{status: http.StatusBadRequest, err: fmt.Errorf("decode request body: %w", err)}
+	}
+
+	return nil
+}
+
+// mediaTypeMatches reports whether a content type matches a media type or a media range like application/*.
+func mediaTypeMatches(mediaRange, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if mediaRange == "*/*" || strings.EqualFold(mediaRange, mediaType) {
+		return true
+	}
+
+	typ, _, _ := strings.Cut(mediaType, "/")
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+
+	return rangeSubtype == "*" && strings.EqualFold(rangeType, typ)
+}
+
+// setHeader sets a response header to a value serialized with the simple style, unless the value is nil.
+func setHeader(w http.ResponseWriter, name string, value any) {
+	if s, ok, _ := formatValue(parameter{name: name, in: "header", style: "simple"}, value, nil); ok {
+		w.Header().Set(name, s)
+	}
+}
+
+// writeJSON writes a response with a JSON body.
+func writeJSON(w http.ResponseWriter, status int, contentType string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode response body: %w", err)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+
+	return nil
+}
+
+// writeBytes writes a response with a raw body.
+func writeBytes(w http.ResponseWriter, status int, contentType string, body []byte) error {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+
+	return nil
+}
+
+// statusOr returns a status code, or the fallback one if it is not set.
+func statusOr(status, fallback int) int {
+	if status == 0 {
+		return fallback
+	}
+
+	return status
+}
+`
+
+// goClientRuntime holds the declarations the generated clients rely on.
+const goClientRuntime = `// newRequest returns the request of an operation, its parameters being serialized according to their style,
+// and its body, if any, being encoded as JSON for a JSON media type or sent as is for a []byte.
+func (c *Client) newRequest(ctx context.Context, method, path string, params []parameterValue, contentType string, body any) (*http.Request, error) {
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+	for _, p := range params {
+		var (
+			s   string
+			ok  bool
+			err error
+		)
+		switch p.in {
+		case "path":
+			if s, ok, err = formatValue(p.parameter, p.value, url.PathEscape); err == nil && !ok {
+				err = errors.New("missing value")
+			}
+			path = strings.ReplaceAll(path, "{"+p.name+"}", s)
+		case "query":
+			err = addQuery(query, p.parameter, p.value)
+		case "header":
+			if s, ok, err = formatValue(p.parameter, p.value, nil); ok {
+				header.Set(p.name, s)
+			}
+		case "cookie":
+			// The exploded form of the cookies is not defined: they are encoded as the non exploded one.
+			p.explode = false
+			if s, ok, err = formatValue(p.parameter, p.value, nil); ok {
+				cookies = append(cookies, &http.Cookie{Name: p.name, Value: s})
+			}
+		default:
+			err = fmt.Errorf("unsupported location %q", p.in)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s parameter %q: %w", p.in, p.name, err)
+		}
+	}
+
+	target := strings.TrimSuffix(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if contentType != "" && !isNil(body) {
+		data, isBytes := body.([]byte)
+		if !isBytes {
+			var err error
+			if data, err = json.Marshal(body); err != nil {
+				return nil, fmt.Errorf("encode request body: %w", err)
+			}
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	return req, nil
+}
+
+// isNil reports whether a value is nil or a nil pointer, slice or map.
+func isNil(value any) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// addQuery adds the value of a query parameter to a query according to its style, unless the value is nil.
+func addQuery(query url.Values, p parameter, value any) error {
+	kind, items, ok := serializeValue(value)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case p.json:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		query.Add(p.name, string(data))
+	case kind == "object" && p.style == "deepObject":
+		for i := 0; i < len(items); i += 2 {
+			query.Add(p.name+"["+items[i]+"]", items[i+1])
+		}
+	case kind == "object" && p.explode:
+		for i := 0; i < len(items); i += 2 {
+			query.Add(items[i], items[i+1])
+		}
+	case kind == "array" && p.explode:
+		for _, item := range items {
+			query.Add(p.name, item)
+		}
+	default:
+		sep := ","
+		switch p.style {
+		case "spaceDelimited":
+			sep = " "
+		case "pipeDelimited":
+			sep = "|"
+		}
+		query.Add(p.name, strings.Join(items, sep))
+	}
+
+	return nil
+}
+
+// do sends a request with the credentials of the first security requirement
+// for which the client holds the credentials of all the schemes.
+func (c *Client) do(req *http.Request, security [][]string) (*http.Response, error) {
+	for _, requirement := range security {
+		satisfied := true
+		for _, scheme := range requirement {
+			satisfied = satisfied && c.hasCredentials(scheme)
+		}
+		if satisfied {
+			for _, scheme := range requirement {
+				c.applyCredentials(req, scheme)
+			}
+			break
+		}
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+// setQuery sets a query parameter of a request.
+func setQuery(req *http.Request, name, value string) {
+	query := req.URL.Query()
+	query.Set(name, value)
+	req.URL.RawQuery = query.Encode()
+}
+
+// decodeHeaders decodes the headers of a response into the pointers of the parameter values.
+// An absent header leaves its value unchanged.
+func decodeHeaders(header http.Header, params []parameterValue) error {
+	for _, p := range params {
+		raw := header.Values(p.name)
+		if len(raw) == 0 {
+			continue
+		}
+
+		target := reflect.ValueOf(p.value).Elem()
+		values, err := splitValue(p.parameter, valueKind(target.Type()), strings.Join(raw, ","))
+		if err == nil {
+			err = setValue(target, values)
+		}
+		if err != nil {
+			return fmt.Errorf("header %q: %w", p.name, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeResponseBody decodes a response body into v: as is into a []byte, as JSON otherwise.
+// An empty body leaves v unchanged.
+func decodeResponseBody(resp *http.Response, v any) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if raw, ok := v.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode response body: %w", err)
+	}
+
+	return nil
+}
+
+// ResponseError is a response with a status code the operation does not declare.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.StatusCode)
+}
+
+func newResponseError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	return &ResponseError{StatusCode: resp.StatusCode, Body: body}
+}
+
+// ServerURL returns the URL of the server of the given index among the servers of the API,
+// its variables being replaced by the given values or by their default ones.
+func ServerURL(index int, variables map[string]string) (string, error) {
+	if index < 0 || index >= len(servers) {
+		return "", fmt.Errorf("no server %d", index)
+	}
+	s := servers[index]
+
+	for name := range variables {
+		if _, ok := s.variables[name]; !ok {
+			return "", fmt.Errorf("unknown server variable %q", name)
+		}
+	}
+
+	serverURL := s.url
+	for name, variable := range s.variables {
+		value, ok := variables[name]
+		if !ok {
+			value = variable.defaultValue
+		}
+
+		if len(variable.enum) > 0 {
+			valid := false
+			for _, e := range variable.enum {
+				valid = valid || e == value
+			}
+			if !valid {
+				return "", fmt.Errorf("server variable %q: %q is not one of %v", name, value, variable.enum)
+			}
+		}
+
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", value)
+	}
+
+	return serverURL, nil
+}
+
+// server is a server of the API.
+type server struct {
+	url       string
+	variables map[string]serverVariable
+}
+
+// serverVariable is a variable of a server URL.
+type serverVariable struct {
+	defaultValue string
+	enum         []string
+}
+`
@@ -0,0 +1,365 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateGoServer returns the gofmt-ed source of a Go file of the given package declaring
+// the types of the component schemas, see GenerateGoTypes, and a server of the operations of the paths:
+//   - a Server interface with a method per operation, named after its operationId, taking the parameters
+//     in a struct and the request body, and returning one of the response types of the operation;
+//   - NewHandler, returning an http.Handler routing the requests to a Server.
+//
+// The handler decodes the parameters according to their style and explode values and the JSON bodies,
+// and checks the enum, minimum, maximum, length, pattern and items constraints of the schemas of the parameters.
+// A missing required parameter or body, a value which cannot be decoded into its type or a parameter violating
+// the constraints of its schema, is answered with a 400 status, an unsupported content type with a 415 status,
+// without calling the server.
+// An error returned by the server is answered with a 500 status.
+// The paths are matched as is: a server URL path has to be stripped beforehand, e.g. with http.StripPrefix.
+func (o *OpenAPI) GenerateGoServer(pkg string) ([]byte, error) {
+	g := newGoTypes(o, "Server", "NewHandler")
+	if err := g.declareComponents(); err != nil {
+		return nil, err
+	}
+
+	operations, err := g.goOperations()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range operations {
+		for i, p := range op.parameters {
+			if p.Schema == nil {
+				continue
+			}
+			if op.parameters[i].constraints, err = g.goConstraints(p.Schema.JSONSchema, nil); err != nil {
+				return nil, fmt.Errorf("%s %q: %s parameter %q: %w", op.method, op.path, p.In, p.Name, err)
+			}
+		}
+	}
+
+	g.decls = append(g.decls, goServerInterface(operations), goServerHandler(operations))
+	for _, op := range operations {
+		g.decls = append(g.decls, goServerWriteResponse(op))
+	}
+	g.decls = append(g.decls, goServerRuntime, goParameterRuntime)
+
+	if len(operations) > 0 {
+		g.imports["context"] = true
+	}
+	for _, path := range []string{
+		"bytes", "encoding/base64", "encoding/json", "errors", "fmt", "io", "mime", "net/http", "net/url",
+		"reflect", "regexp", "sort", "strconv", "strings", "time", "unicode/utf8",
+	} {
+		g.imports[path] = true
+	}
+
+	return goFile(pkg, g.imports, g.body())
+}
+
+// goServerHandlerType is the declaration of the http.Handler of a server.
+const goServerHandlerType = `// NewHandler returns an http.Handler routing the requests to the operations of a Server.
+func NewHandler(server Server) http.Handler {
+	return &handler{server: server}
+}
+
+// handler is the http.Handler of a Server.
+type handler struct {
+	server Server
+}
+
+`
+
+// goServerInterface returns the declaration of the interface of the operations.
+func goServerInterface(operations []goOperation) string {
+	var b strings.Builder
+	b.WriteString("// Server is implemented by the handlers of the operations.\n")
+	b.WriteString("type Server interface {\n")
+	for i, op := range operations {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(operationDoc(op, "serves"))
+		fmt.Fprintf(&b, "\t%s(%s) %s\n", op.name, goOperationArgs(op), goOperationResults(op))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// goOperationArgs returns the arguments of the method of an operation: the context, the parameters and the body.
+func goOperationArgs(op goOperation) string {
+	args := []string{"ctx context.Context"}
+	if op.params != "" {
+		args = append(args, "params "+op.params)
+	}
+	if op.body != nil {
+		args = append(args, "body "+goBodyType(*op.body))
+	}
+
+	return strings.Join(args, ", ")
+}
+
+// goOperationResults returns the results of the method of an operation.
+func goOperationResults(op goOperation) string {
+	if op.response == "" {
+		return "error"
+	}
+
+	return "(" + op.response + ", error)"
+}
+
+// goBodyType returns the Go type of a body, a pointer if it is optional.
+func goBodyType(body goBody) string {
+	if !body.required && !isNillableGoType(body.typ) {
+		return "*" + body.typ
+	}
+
+	return body.typ
+}
+
+// goServerHandler returns the declarations of the http.Handler of a server: its routes and a method per operation.
+func goServerHandler(operations []goOperation) string {
+	var b strings.Builder
+	b.WriteString(goServerHandlerType)
+
+	type route struct {
+		op      goOperation
+		pattern string
+		params  []string
+	}
+	routes := make([]route, 0, len(operations))
+	for _, op := range operations {
+		pattern, params := pathPattern(op.path)
+		routes = append(routes, route{op: op, pattern: pattern, params: params})
+	}
+	// The concrete paths are matched before the templated ones.
+	sort.SliceStable(routes, func(i, j int) bool { return len(routes[i].params) < len(routes[j].params) })
+
+	b.WriteString("// routes lists the operations, the concrete paths first.\n")
+	b.WriteString("var routes = []route{\n")
+	for _, r := range routes {
+		var params string
+		if len(r.params) > 0 {
+			quoted := make([]string, 0, len(r.params))
+			for _, p := range r.params {
+				quoted = append(quoted, strconv.Quote(p))
+			}
+			params = fmt.Sprintf(" params: []string{%s},", strings.Join(quoted, ", "))
+		}
+		fmt.Fprintf(&b, "\t{method: %q, pattern: regexp.MustCompile(%q),%s handle: (*handler).handle%s},\n",
+			r.op.method, r.pattern, params, r.op.name)
+	}
+	b.WriteString("}\n")
+
+	for _, op := range operations {
+		b.WriteByte('\n')
+		b.WriteString(goServerHandleOperation(op))
+	}
+
+	return b.String()
+}
+
+// goServerHandleOperation returns the method of the handler decoding the request of an operation,
+// calling the server and writing its response.
+func goServerHandleOperation(op goOperation) string {
+	var (
+		b      strings.Builder
+		checks []string
+	)
+	for _, p := range op.parameters {
+		if p.constraints == "" {
+			continue
+		}
+		name := goUnexportedName(op.name + p.field + "Constraints")
+		fmt.Fprintf(&b, "// %s are the constraints of the %s %s parameter of %s.\n", name, p.Name, p.In, op.name)
+		fmt.Fprintf(&b, "var %s = %s\n\n", name, p.constraints)
+		checks = append(checks, fmt.Sprintf("\t\t{%q, %q, params.%s, %s},\n", p.In, p.Name, p.field, name))
+	}
+
+	fmt.Fprintf(&b, "func (h *handler) handle%s(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {\n", op.name)
+
+	args := []string{"r.Context()"}
+	if op.params != "" {
+		fmt.Fprintf(&b, "\tvar params %s\n", op.params)
+		b.WriteString("\tif err := decodeParameters(r, pathParams, []parameterValue{\n")
+		for _, p := range op.parameters {
+			fmt.Fprintf(&b, "\t\t{%s, &params.%s},\n", goParameterSpec(p), p.field)
+		}
+		b.WriteString("\t}); err != nil {\n\t\tfail(w, err)\n\t\treturn\n\t}\n\n")
+		if len(checks) > 0 {
+			b.WriteString("\tif err := checkParameters([]parameterCheck{\n")
+			b.WriteString(strings.Join(checks, ""))
+			b.WriteString("\t}); err != nil {\n\t\tfail(w, err)\n\t\treturn\n\t}\n\n")
+		}
+		args = append(args, "params")
+	}
+
+	if op.body != nil {
+		fmt.Fprintf(&b, "\tvar body %s\n", goBodyType(*op.body))
+		fmt.Fprintf(&b, "\tif err := decodeBody(r, %q, %t, &body); err != nil {\n\t\tfail(w, err)\n\t\treturn\n\t}\n\n",
+			op.body.contentType, op.body.required)
+		args = append(args, "body")
+	}
+
+	if op.response == "" {
+		fmt.Fprintf(&b, "\tif err := h.server.%s(%s); err != nil {\n\t\tfail(w, err)\n\t\treturn\n\t}\n", op.name, strings.Join(args, ", "))
+		b.WriteString("\tw.WriteHeader(http.StatusNoContent)\n}\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\tresponse, err := h.server.%s(%s)\n", op.name, strings.Join(args, ", "))
+	b.WriteString("\tif err != nil {\n\t\tfail(w, err)\n\t\treturn\n\t}\n\n")
+	fmt.Fprintf(&b, "\tif err := write%s(w, response); err != nil {\n\t\tfail(w, err)\n\t}\n}\n", op.response)
+
+	return b.String()
+}
+
+// goConstraints returns the literal of the constraints of a parameter schema checked by the generated server,
+// empty if it has none. The objects are not checked.
+// The visited references stop the recursion of the recursive array schemas.
+func (g *goTypes) goConstraints(schema JSONSchema, visited []string) (string, error) {
+	if schema.Ref != "" {
+		if containsString(visited, schema.Ref) {
+			return "", nil
+		}
+		visited = append(visited, schema.Ref)
+	}
+	schema, err := g.doc.flattenSchema(schema)
+	if err != nil {
+		return "", err
+	}
+	if isStructSchema(schema) || schema.HasType("object") {
+		return "", nil
+	}
+
+	var fields []string
+	if len(schema.Enum) > 0 {
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return "", fmt.Errorf("enum: %w", err)
+			}
+			values = append(values, strconv.Quote(string(data)))
+		}
+		fields = append(fields, fmt.Sprintf("enum: []string{%s}", strings.Join(values, ", ")))
+	}
+	for _, bound := range []struct {
+		name  string
+		value *float64
+	}{
+		{"minimum", schema.Minimum},
+		{"maximum", schema.Maximum},
+		{"exclusiveMinimum", schema.exclusiveMinimum()},
+		{"exclusiveMaximum", schema.exclusiveMaximum()},
+	} {
+		if bound.value != nil {
+			fields = append(fields, fmt.Sprintf("%s: bound(%s)", bound.name, strconv.FormatFloat(*bound.value, 'g', -1, 64)))
+		}
+	}
+	for _, length := range []struct {
+		name  string
+		value int
+	}{
+		{"minLength", schema.MinLength},
+		{"maxLength", schema.MaxLength},
+		{"minItems", schema.MinItems},
+		{"maxItems", schema.MaxItems},
+	} {
+		if length.value > 0 {
+			fields = append(fields, fmt.Sprintf("%s: %d", length.name, length.value))
+		}
+	}
+	if schema.Pattern != "" {
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			return "", fmt.Errorf("pattern: %w", err)
+		}
+		fields = append(fields, fmt.Sprintf("pattern: regexp.MustCompile(%q)", schema.Pattern))
+	}
+	if schema.Items != nil {
+		items, err := g.goConstraints(*schema.Items, visited)
+		if err != nil {
+			return "", fmt.Errorf("items: %w", err)
+		}
+		if items != "" {
+			fields = append(fields, "items: "+items)
+		}
+	}
+
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	return "&constraints{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// goParameterSpec returns the literal of the serialization of a parameter.
+func goParameterSpec(p goParameter) string {
+	fields := []string{fmt.Sprintf("name: %q", p.Name), fmt.Sprintf("in: %q", p.In), fmt.Sprintf("style: %q", p.style)}
+	if p.explode {
+		fields = append(fields, "explode: true")
+	}
+	if p.required {
+		fields = append(fields, "required: true")
+	}
+	if isJSONMediaType(p.contentType) {
+		fields = append(fields, "json: true")
+	}
+
+	return "parameter{" + strings.Join(fields, ", ") + "}"
+}
+
+// goServerWriteResponse returns the function writing the responses of an operation.
+func goServerWriteResponse(op goOperation) string {
+	if op.response == "" {
+		return ""
+	}
+
+	var (
+		cases strings.Builder
+		used  bool
+	)
+	for _, r := range op.responses {
+		fmt.Fprintf(&cases, "\tcase %s:\n", r.typeName)
+		for _, h := range r.headers {
+			fmt.Fprintf(&cases, "\t\tsetHeader(w, %q, response.%s)\n", h.Name, h.field)
+			used = true
+		}
+
+		status := strconv.Itoa(r.status())
+		if r.status() == 0 {
+			status = fmt.Sprintf("statusOr(response.StatusCode, %d)", r.fallbackStatus())
+			used = true
+		}
+
+		switch {
+		case r.body == nil:
+			fmt.Fprintf(&cases, "\t\tw.WriteHeader(%s)\n\t\treturn nil\n", status)
+		case isJSONMediaType(r.body.contentType):
+			fmt.Fprintf(&cases, "\t\treturn writeJSON(w, %s, %q, response.Body)\n", status, r.body.contentType)
+			used = true
+		default:
+			fmt.Fprintf(&cases, "\t\treturn writeBytes(w, %s, %q, response.Body)\n", status, r.body.contentType)
+			used = true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// write%[1]s writes a response of %[2]s.\n", op.response, op.name)
+	fmt.Fprintf(&b, "func write%s(w http.ResponseWriter, response %s) error {\n", op.response, op.response)
+	if used {
+		b.WriteString("\tswitch response := response.(type) {\n")
+	} else {
+		b.WriteString("\tswitch response.(type) {\n")
+	}
+	b.WriteString(cases.String())
+	b.WriteString("\tdefault:\n\t\treturn fmt.Errorf(\"unexpected response %T\", response)\n\t}\n}\n")
+
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package openapiv3
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_GenerateGoServer(t *testing.T) {
+	oas, err := FromFile("testdata/gooperations.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoServer("pets")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/goserver.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+
+	typeCheck(t, src)
+}
+
+func TestOpenAPI_GenerateGoServer_handler(t *testing.T) {
+	oas, err := FromFile("testdata/gooperations.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoServer("main")
+	require.NoError(t, err)
+
+	main, err := os.ReadFile("testdata/goserver_main.go")
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		target   string
+		expected string
+	}{
+		{
+			desc:     "form",
+			target:   "/pets?limit=10&tags=a&tags=bc&status=sold",
+			expected: `200 {"Limit":10,"Tags":["a","bc"],"Status":"sold","Filter":null,"XRequestID":"id"}`,
+		},
+		{desc: "form minimum", target: "/pets?limit=0", expected: `400 query parameter "limit": 0 is less than 1`},
+		{desc: "form maximum", target: "/pets?limit=101", expected: `400 query parameter "limit": 101 is greater than 100`},
+		{
+			desc:     "form maxItems",
+			target:   "/pets?tags=a&tags=b&tags=c&tags=d",
+			expected: `400 query parameter "tags": 4 items are more than 3`,
+		},
+		{
+			desc:     "form items pattern",
+			target:   "/pets?tags=a&tags=B",
+			expected: `400 query parameter "tags": item 1: "B" does not match ^[a-z]+$`,
+		},
+		{
+			desc:     "form items maxLength",
+			target:   "/pets?tags=abcdefghi",
+			expected: `400 query parameter "tags": item 0: "abcdefghi" is longer than 8 characters`,
+		},
+		{
+			desc:     "form enum",
+			target:   "/pets?status=lost",
+			expected: `400 query parameter "status": "lost" is not one of "available", "sold"`,
+		},
+		{desc: "simple", target: "/pets/42", expected: `200 {"PetID":42}`},
+		{desc: "simple exclusiveMinimum", target: "/pets/0", expected: `400 path parameter "petId": 0 is less than or equal to 0`},
+		{desc: "simple invalid", target: "/pets/rex", expected: `400 path parameter "petId": "rex" is not an integer`},
+		{desc: "label", target: "/sizes/.1,2,3", expected: `204 {"Sizes":[1,2,3]}`},
+		{desc: "label items maximum", target: "/sizes/.1,11", expected: `400 path parameter "sizes": item 1: 11 is greater than 10`},
+		{desc: "label invalid", target: "/sizes/1,2", expected: `400 path parameter "sizes": "1,2" is not a label value`},
+		{desc: "matrix", target: "/colors/;colors=red;colors=blue", expected: `200 {"Colors":["red","blue"],"Point":null}`},
+		{
+			desc:     "matrix items enum",
+			target:   "/colors/;colors=red;colors=pink",
+			expected: `400 path parameter "colors": item 1: "pink" is not one of "red", "green", "blue"`,
+		},
+	}
+
+	targets := make([]string, 0, len(tests))
+	for _, test := range tests {
+		targets = append(targets, test.target)
+	}
+
+	out := runGo(t, map[string]string{"server.go": string(src), "main.go": string(main)}, targets...)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, len(tests))
+	for i, test := range tests {
+		assert.Equal(t, test.expected, lines[i], test.desc)
+	}
+}
+
+func TestOpenAPI_GenerateGoServer_errors(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/pets": PathItem{Get: &Operation{
+				Parameters: []Parameter{{Reference: Reference{Ref: "#/components/parameters/Limit"}}},
+			}},
+		},
+	}
+
+	_, err := oas.GenerateGoServer("pets")
+	assert.EqualError(t, err, `GET "/pets": unresolved reference "#/components/parameters/Limit"`)
+}
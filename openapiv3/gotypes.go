@@ -0,0 +1,608 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GenerateGoTypes returns the gofmt-ed source of a Go file of the given package
+// declaring a type for each schema of the components.
+//
+// The types follow the schemas:
+//   - an object is a struct, where the optional and the nullable properties are pointers,
+//     and the allOf referenced schemas are embedded, unless their type has JSON methods
+//     which would be promoted to the struct: their properties are then declared by the struct;
+//   - an object with additionalProperties only is a map, otherwise the additional properties
+//     are held in an AdditionalProperties field;
+//   - an enum is a named type with a constant for each value;
+//   - a oneOf or anyOf of references with a discriminator is a struct holding one of the referenced types,
+//     decoded according to the discriminator property; the other ones are json.RawMessage;
+//   - the date-time strings are time.Time, the byte strings are []byte, and the numbers are sized by their format.
+//
+// The inline schemas needing a type declaration are named after their parent and property.
+func (o *OpenAPI) GenerateGoTypes(pkg string) ([]byte, error) {
+	g := newGoTypes(o)
+	if err := g.declareComponents(); err != nil {
+		return nil, err
+	}
+
+	return goFile(pkg, g.imports, g.body())
+}
+
+// goTypes generates the Go type declarations of schemas.
+type goTypes struct {
+	doc *OpenAPI
+	// components holds the Go type names of the component schemas.
+	components map[string]string
+	names      goNames
+	// operationNames holds the Go method names of the operations.
+	operationNames goNames
+	imports        map[string]bool
+	// decls holds the declarations, each parent type before its inline types.
+	decls []string
+}
+
+// newGoTypes returns a goTypes for the schemas of a document,
+// the reserved names being kept for the declarations of the generated code.
+func newGoTypes(doc *OpenAPI, reserved ...string) *goTypes {
+	g := &goTypes{
+		doc:            doc,
+		components:     map[string]string{},
+		names:          goNames{},
+		operationNames: goNames{},
+		imports:        map[string]bool{},
+	}
+	for _, name := range reserved {
+		g.names[name] = true
+	}
+
+	for _, name := range sortedKeys(doc.components().Schemas) {
+		typeName := goName(name)
+		if typeName == "" {
+			typeName = "Schema"
+		}
+		g.components[name] = g.names.unique(typeName)
+	}
+
+	return g
+}
+
+func (g *goTypes) declareComponents() error {
+	schemas := g.doc.components().Schemas
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		if err := g.declare(g.components[name], schema.JSONSchema, schema.Discriminator); err != nil {
+			return fmt.Errorf("schema %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *goTypes) body() string {
+	return strings.Join(g.decls, "\n")
+}
+
+// declare adds the declaration of a named type for a schema.
+func (g *goTypes) declare(name string, schema JSONSchema, discriminator *Discriminator) error {
+	slot := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	var b strings.Builder
+	b.WriteString(goComment(name, schemaDoc(schema)))
+
+	var err error
+	switch {
+	case schema.Ref != "":
+		var target string
+		if target, err = g.refType(schema.Ref); err == nil {
+			fmt.Fprintf(&b, "type %s = %s\n", name, target)
+		}
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		err = g.declareUnion(&b, name, schema, discriminator)
+	case len(schema.Enum) > 0 && enumBaseType(schema) != "":
+		g.declareEnum(&b, name, schema)
+	case isStructSchema(schema):
+		err = g.declareStruct(&b, name, schema)
+	default:
+		var typ string
+		if typ, err = g.goType(schema, name); err == nil {
+			fmt.Fprintf(&b, "type %s %s\n", name, typ)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	g.decls[slot] = b.String()
+
+	return nil
+}
+
+// goType returns the Go type of a schema, declaring a type named after the context if needed.
+func (g *goTypes) goType(schema JSONSchema, context string) (string, error) {
+	if schema.Ref != "" {
+		return g.refType(schema.Ref)
+	}
+
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		g.imports["encoding/json"] = true
+		return "json.RawMessage", nil
+	}
+
+	if len(schema.AllOf) == 1 && schema.AllOf[0].Ref != "" && !isStructSchema(JSONSchema{Properties: schema.Properties}) {
+		return g.refType(schema.AllOf[0].Ref)
+	}
+
+	if (len(schema.Enum) > 0 && enumBaseType(schema) != "") || isStructSchema(schema) {
+		name := g.names.unique(context)
+		if err := g.declare(name, schema, nil); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+
+	switch schemaGoKind(schema) {
+	case "object":
+		additional, allowed := schema.AdditionalPropertiesSchema()
+		if !allowed || additional == nil {
+			return "map[string]any", nil
+		}
+		valueType, err := g.goType(*additional, context+"Value")
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + valueType, nil
+	case "array":
+		if schema.Items == nil {
+			return "[]any", nil
+		}
+		itemType, err := g.goType(schema.itemsSchema(), context+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+			return "time.Time", nil
+		case "byte":
+			return "[]byte", nil
+		default:
+			return "string", nil
+		}
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32", nil
+		case "int64":
+			return "int64", nil
+		default:
+			return "int", nil
+		}
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	default:
+		return "any", nil
+	}
+}
+
+// refType returns the Go type of a referenced component schema.
+func (g *goTypes) refType(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", fmt.Errorf("unsupported reference %q", ref)
+	}
+
+	typeName, ok := g.components[name]
+	if !ok {
+		return "", fmt.Errorf("unresolved reference %q", ref)
+	}
+
+	return typeName, nil
+}
+
+// schemaGoKind returns the single non-null type of a schema, or the type implied by its keywords,
+// empty if the schema allows several types.
+func schemaGoKind(schema JSONSchema) string {
+	var kinds []string
+	for _, t := range schema.Types() {
+		if t != "null" {
+			kinds = append(kinds, t)
+		}
+	}
+
+	switch {
+	case len(kinds) == 1:
+		return kinds[0]
+	case len(kinds) > 1:
+		return ""
+	case len(schema.Properties) > 0 || schema.AdditionalProperties != nil:
+		return "object"
+	case schema.Items != nil:
+		return "array"
+	default:
+		return ""
+	}
+}
+
+// isStructSchema reports whether a schema is declared as a struct: an object with properties or allOf subschemas.
+func isStructSchema(schema JSONSchema) bool {
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 0
+}
+
+// schemaDoc returns the documentation of a schema: its description, or its title.
+func schemaDoc(schema JSONSchema) string {
+	if schema.Description != "" {
+		return schema.Description
+	}
+
+	return schema.Title
+}
+
+// enumBaseType returns the Go type of the values of an enum, empty if they are not all strings or all numbers.
+func enumBaseType(schema JSONSchema) string {
+	var base string
+	for _, value := range schema.Enum {
+		var typ string
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			typ = "string"
+		default:
+			n, ok := toFloat(v)
+			if !ok {
+				return ""
+			}
+			typ = "float64"
+			if schema.HasType("integer") || !schema.HasType("number") && n == float64(int64(n)) {
+				typ = "int"
+			}
+		}
+
+		switch {
+		case base == "":
+			base = typ
+		case base == "int" && typ == "float64" || base == "float64" && typ == "int":
+			base = "float64"
+		case base != typ:
+			return ""
+		}
+	}
+
+	return base
+}
+
+func (g *goTypes) declareEnum(b *strings.Builder, name string, schema JSONSchema) {
+	base := enumBaseType(schema)
+	fmt.Fprintf(b, "type %s %s\n\n", name, base)
+
+	fmt.Fprintf(b, "// Values of %s.\n", name)
+	b.WriteString("const (\n")
+	for _, value := range schema.Enum {
+		if value == nil {
+			continue
+		}
+
+		var suffix, literal string
+		switch v := value.(type) {
+		case string:
+			suffix, literal = goName(v), strconv.Quote(v)
+			if suffix == "" {
+				suffix = "Empty"
+			}
+		default:
+			n, _ := toFloat(v)
+			literal = strconv.FormatFloat(n, 'f', -1, 64)
+			suffix = strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+		}
+
+		fmt.Fprintf(b, "\t%s %s = %s\n", g.names.unique(name+suffix), name, literal)
+	}
+	b.WriteString(")\n")
+}
+
+func (g *goTypes) declareStruct(b *strings.Builder, name string, schema JSONSchema) error {
+	var (
+		embedded   []string
+		properties = map[string]JSONSchema{}
+		required   = append([]string(nil), schema.Required...)
+		// composed holds the additional properties of the struct, the ones of a flattened allOf schema by default.
+		composed = JSONSchema{AdditionalProperties: schema.AdditionalProperties}
+	)
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+
+	for _, sub := range schema.AllOf {
+		if sub.Ref != "" {
+			methods, err := g.hasJSONMethods(sub, nil)
+			if err != nil {
+				return err
+			}
+			if !methods {
+				typ, err := g.refType(sub.Ref)
+				if err != nil {
+					return err
+				}
+				embedded = append(embedded, typ)
+				continue
+			}
+		}
+
+		sub, err := g.doc.flattenSchema(sub)
+		if err != nil {
+			return err
+		}
+		if len(sub.OneOf) > 0 || len(sub.AnyOf) > 0 {
+			return fmt.Errorf("allOf: the oneOf and anyOf schemas cannot be composed into a struct")
+		}
+		for name, property := range sub.Properties {
+			properties[name] = property
+		}
+		required = append(required, sub.Required...)
+		if composed.AdditionalProperties == nil {
+			composed.AdditionalProperties = sub.AdditionalProperties
+		}
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, typ := range embedded {
+		fmt.Fprintf(b, "\t%s\n", typ)
+	}
+
+	fields := goNames{}
+	var propertyNames []string
+	for _, property := range sortedKeys(properties) {
+		fieldName := goName(property)
+		if fieldName == "" {
+			fieldName = "Field"
+		}
+		fieldName = fields.unique(fieldName)
+		propertyNames = append(propertyNames, strconv.Quote(property))
+
+		propertySchema := properties[property]
+		typ, err := g.goType(propertySchema, name+fieldName)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", property, err)
+		}
+
+		tag := property
+		isRequired := containsString(required, property)
+		if !isRequired {
+			tag += ",omitempty"
+		}
+		if (!isRequired || propertySchema.HasType("null")) && !isNillableGoType(typ) {
+			typ = "*" + typ
+		}
+
+		if doc := goComment(fieldName, schemaDoc(propertySchema)); doc != "" {
+			b.WriteString(doc)
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", fieldName, typ, tag)
+	}
+
+	additional, allowed := composed.AdditionalPropertiesSchema()
+	hasAdditional := composed.AdditionalProperties != nil && allowed
+	valueType := "any"
+	if hasAdditional && additional != nil {
+		var err error
+		if valueType, err = g.goType(*additional, name+"Value"); err != nil {
+			return fmt.Errorf("additionalProperties: %w", err)
+		}
+	}
+	if hasAdditional {
+		b.WriteString("\t// AdditionalProperties holds the properties not declared by the schema.\n")
+		fmt.Fprintf(b, "\tAdditionalProperties map[string]%s `json:\"-\"`\n", valueType)
+	}
+	b.WriteString("}\n")
+
+	if hasAdditional {
+		g.imports["encoding/json"] = true
+		g.imports["fmt"] = true
+		fmt.Fprintf(b, goAdditionalPropertiesMethods, name, valueType, strings.Join(propertyNames, ", "))
+	}
+
+	return nil
+}
+
+// goAdditionalPropertiesMethods is the template of the JSON methods of a struct with additional properties,
+// formatted with the type name, the type of the additional properties and the quoted property names.
+const goAdditionalPropertiesMethods = `
+// UnmarshalJSON decodes the declared properties into the fields and the other ones into AdditionalProperties.
+func (v *%[1]s) UnmarshalJSON(data []byte) error {
+	type plain %[1]s
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	for _, name := range []string{%[3]s} {
+		delete(properties, name)
+	}
+
+	v.AdditionalProperties = nil
+	for name, raw := range properties {
+		var value %[2]s
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("%%s: %%w", name, err)
+		}
+		if v.AdditionalProperties == nil {
+			v.AdditionalProperties = make(map[string]%[2]s, len(properties))
+		}
+		v.AdditionalProperties[name] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the fields along with the AdditionalProperties.
+func (v %[1]s) MarshalJSON() ([]byte, error) {
+	type plain %[1]s
+	data, err := json.Marshal(plain(v))
+	if err != nil || len(v.AdditionalProperties) == 0 {
+		return data, err
+	}
+
+	var properties map[string]any
+	if err = json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	for name, value := range v.AdditionalProperties {
+		properties[name] = value
+	}
+
+	return json.Marshal(properties)
+}
+`
+
+// hasJSONMethods reports whether the type declared for a schema has JSON methods:
+// the unions, and the structs with additional properties, their own or the ones of a flattened allOf schema.
+// The visited references stop the recursion of the recursive schemas.
+func (g *goTypes) hasJSONMethods(schema JSONSchema, visited []string) (bool, error) {
+	if schema.Ref != "" {
+		if containsString(visited, schema.Ref) {
+			return false, nil
+		}
+		resolved, err := (&valueValidator{doc: g.doc}).resolve(schema)
+		if err != nil {
+			return false, err
+		}
+		return g.hasJSONMethods(resolved, append(visited, schema.Ref))
+	}
+
+	switch {
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		return true, nil
+	case !isStructSchema(schema):
+		return false, nil
+	}
+
+	if _, allowed := schema.AdditionalPropertiesSchema(); schema.AdditionalProperties != nil && allowed {
+		return true, nil
+	}
+	for _, sub := range schema.AllOf {
+		if methods, err := g.hasJSONMethods(sub, visited); methods || err != nil {
+			return methods, err
+		}
+	}
+
+	return false, nil
+}
+
+func isNillableGoType(typ string) bool {
+	return typ == "any" || typ == "json.RawMessage" || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[")
+}
+
+// declareUnion declares a oneOf or anyOf schema: a struct holding one of the referenced types
+// if a discriminator is defined, a json.RawMessage otherwise.
+func (g *goTypes) declareUnion(b *strings.Builder, name string, schema JSONSchema, discriminator *Discriminator) error {
+	g.imports["encoding/json"] = true
+
+	branches := append(append([]JSONSchema(nil), schema.OneOf...), schema.AnyOf...)
+	variants := make([]string, 0, len(branches))
+	refs := map[string]string{}
+	for _, branch := range branches {
+		if discriminator == nil || branch.Ref == "" {
+			fmt.Fprintf(b, "type %s = json.RawMessage\n", name)
+			return nil
+		}
+
+		typ, err := g.refType(branch.Ref)
+		if err != nil {
+			return err
+		}
+		variants = append(variants, typ)
+		refs[branch.Ref] = typ
+	}
+
+	// The discriminator values are the mapped ones, then the names of the schemas which are not mapped.
+	var cases []string
+	values := map[string][]string{}
+	mapped := map[string]bool{}
+	for _, value := range sortedKeys(discriminator.Mapping) {
+		ref := discriminator.Mapping[value]
+		if !strings.HasPrefix(ref, "#") {
+			ref = "#/components/schemas/" + ref
+		}
+		typ, ok := refs[ref]
+		if !ok {
+			return fmt.Errorf("discriminator mapping %q: %q is not one of the schemas", value, ref)
+		}
+		values[typ] = append(values[typ], strconv.Quote(value))
+		mapped[ref] = true
+	}
+	for _, branch := range branches {
+		if !mapped[branch.Ref] {
+			typ := refs[branch.Ref]
+			values[typ] = append(values[typ], strconv.Quote(strings.TrimPrefix(branch.Ref, "#/components/schemas/")))
+		}
+	}
+	for _, typ := range variants {
+		if len(values[typ]) > 0 {
+			cases = append(cases, fmt.Sprintf(goUnionCase, strings.Join(values[typ], ", "), typ))
+		}
+	}
+
+	g.imports["fmt"] = true
+	marker := "is" + name
+	fieldName := goName(discriminator.PropertyName)
+	if fieldName == "" {
+		fieldName = "Value"
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n\t// Value is one of %s.\n\tValue %sValue\n}\n\n", name, strings.Join(variants, ", "), name)
+	fmt.Fprintf(b, "// %[1]sValue is implemented by the types a %[1]s can hold.\ntype %[1]sValue interface {\n\t%[2]s()\n}\n\n", name, marker)
+	for _, typ := range variants {
+		fmt.Fprintf(b, "func (%s) %s() {}\n\n", typ, marker)
+	}
+	fmt.Fprintf(b, goUnionMethods, name, fieldName, discriminator.PropertyName, strings.Join(cases, ""))
+
+	return nil
+}
+
+// goUnionMethods is the template of the JSON methods of a discriminated union,
+// formatted with the type name, the discriminator field and property names, and the cases of the types.
+const goUnionMethods = `// MarshalJSON encodes the held value.
+func (v %[1]s) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+// UnmarshalJSON decodes the value of the type designated by the %[3]s property.
+func (v *%[1]s) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		%[2]s string ` + "`json:%[3]q`" + `
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+
+	switch discriminator.%[2]s {%[4]s
+	default:
+		return fmt.Errorf("unknown %[3]s %%q", discriminator.%[2]s)
+	}
+
+	return nil
+}
+`
+
+// goUnionCase is the template of a case of the UnmarshalJSON method of a discriminated union,
+// formatted with the quoted discriminator values and the type.
+const goUnionCase = `
+	case %s:
+		var value %s
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		v.Value = value`
@@ -0,0 +1,191 @@
+package openapiv3
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_GenerateGoTypes(t *testing.T) {
+	oas, err := FromFile("testdata/gotypes.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoTypes("pets")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/gotypes.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+
+	typeCheck(t, src)
+}
+
+func TestOpenAPI_GenerateGoTypes_roundTrip(t *testing.T) {
+	oas, err := FromFile("testdata/gotypes.yaml")
+	require.NoError(t, err)
+
+	src, err := oas.GenerateGoTypes("main")
+	require.NoError(t, err)
+
+	tests := []struct {
+		typ  string
+		data string
+	}{
+		{typ: "BasePet", data: `{"id":"1","petType":"Cat","born_at":"2020-01-02T00:00:00Z","status":"available"}`},
+		{typ: "Cat", data: `{"id":"1","petType":"Cat","born_at":"2020-01-02T00:00:00Z","lives":7}`},
+		{typ: "Pet", data: `{"id":"2","petType":"dog","born_at":"2020-01-02T00:00:00Z","tricks":["sit"]}`},
+		{typ: "Labels", data: `{"color":"red"}`},
+		{typ: "Toy", data: `{"name":"duck","color":1}`},
+		{typ: "SqueakyToy", data: `{"name":"duck","sound":"squeak","color":1}`},
+		{typ: "Payload", data: `42`},
+	}
+
+	main := "\nfunc main() {\n"
+	for _, test := range tests {
+		main += fmt.Sprintf("\troundTrip(&%s{}, %q)\n", test.typ, test.data)
+	}
+	main += `}
+
+func roundTrip(v any, data string) {
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		panic(err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(out))
+}
+`
+
+	out := runGo(t, map[string]string{
+		"types.go": string(src),
+		"main.go":  "package main\n\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n)\n" + main,
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, len(tests))
+	for i, test := range tests {
+		assert.JSONEq(t, test.data, lines[i], test.typ)
+	}
+}
+
+func TestOpenAPI_GenerateGoTypes_errors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		schemas  map[string]Schema
+		expected string
+	}{
+		{
+			desc:     "unresolved reference",
+			schemas:  map[string]Schema{"Pets": {JSONSchema: JSONSchema{Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/Pet"}}}},
+			expected: `schema "Pets": unresolved reference "#/components/schemas/Pet"`,
+		},
+		{
+			desc: "unknown discriminator mapping",
+			schemas: map[string]Schema{
+				"Cat": {JSONSchema: JSONSchema{Type: "object"}},
+				"Pet": {
+					JSONSchema:    JSONSchema{OneOf: []JSONSchema{{Ref: "#/components/schemas/Cat"}}},
+					Discriminator: &Discriminator{PropertyName: "type", Mapping: map[string]string{"dog": "Dog"}},
+				},
+			},
+			expected: `schema "Pet": discriminator mapping "dog": "#/components/schemas/Dog" is not one of the schemas`,
+		},
+		{
+			desc: "union composed into a struct",
+			schemas: map[string]Schema{
+				"Payload": {JSONSchema: JSONSchema{AnyOf: []JSONSchema{{Type: "string"}, {Type: "integer"}}}},
+				"Tagged":  {JSONSchema: JSONSchema{AllOf: []JSONSchema{{Ref: "#/components/schemas/Payload"}}}},
+			},
+			expected: `schema "Tagged": allOf: the oneOf and anyOf schemas cannot be composed into a struct`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			oas := &OpenAPI{Components: &Components{Schemas: test.schemas}}
+			_, err := oas.GenerateGoTypes("pets")
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"pet":         "Pet",
+		"petId":       "PetID",
+		"pet_id":      "PetID",
+		"HTTPServer":  "HTTPServer",
+		"on-hold":     "OnHold",
+		"x-rate-url":  "XRateURL",
+		"2fa":         "N2fa",
+		"user.name":   "UserName",
+		"APIResponse": "APIResponse",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, goName(name), name)
+	}
+
+	assert.Equal(t, "petID", goUnexportedName("PetID"))
+	assert.Equal(t, "idToken", goUnexportedName("id_token"))
+	assert.Equal(t, "type_", goUnexportedName("type"))
+}
+
+// typeCheck parses and type-checks a generated Go file.
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	conf := types.Config{Importer: importer.Default()}
+	_, err = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+// runGo runs a main package made of the given files with the given arguments, and returns its output.
+func runGo(t *testing.T, files map[string]string, args ...string) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("running a generated program is skipped in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+
+	dir := t.TempDir()
+	files["go.mod"] = "module generated\n\ngo 1.20\n"
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	cmd := exec.Command(goBin, append([]string{"run", "."}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off")
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		t.Fatalf("go run: %v\n%s", err, exitErr.Stderr)
+	}
+	require.NoError(t, err)
+
+	return string(out)
+}
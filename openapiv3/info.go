@@ -48,6 +48,8 @@ type Info struct {
 	License *License `json:"license,omitempty"`
 	// REQUIRED. The version of the OpenAPI document (which is distinct from the OpenAPI Specification version or the API implementation version).
 	Version string `json:"version"`
+	// Extensions are the Specification Extensions of the info.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates an Info.
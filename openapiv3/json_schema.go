@@ -1,15 +1,131 @@
 package openapiv3
 
+import "encoding/json"
+
 // JSONSchema represents a very naive implementation of [JSON Schema Specification].
 //
 // [JSON Schema Specification]: https://json-schema.org/specification.html
 type JSONSchema struct {
-	Format     string                `json:"format,omitempty"`
-	Items      map[string]string     `json:"items,omitempty"`
-	Maximum    int                   `json:"maximum,omitempty"`
-	MaxItems   int                   `json:"maxItems,omitempty"`
-	Properties map[string]JSONSchema `json:"properties,omitempty"`
-	Ref        string                `json:"$ref,omitempty"`
-	Required   []string              `json:"required,omitempty"`
-	Type       string                `json:"type,omitempty"`
+	// AdditionalProperties is either a boolean or a schema (JSONSchema, *JSONSchema or its decoded JSON form).
+	AdditionalProperties any          `json:"additionalProperties,omitempty"`
+	AllOf                []JSONSchema `json:"allOf,omitempty"`
+	AnyOf                []JSONSchema `json:"anyOf,omitempty"`
+	Description          string       `json:"description,omitempty"`
+	Enum                 []any        `json:"enum,omitempty"`
+	// ExclusiveMaximum and ExclusiveMinimum are either numbers or, in OpenAPI 3.0, booleans
+	// making the maximum and the minimum exclusive.
+	ExclusiveMaximum any                   `json:"exclusiveMaximum,omitempty"`
+	ExclusiveMinimum any                   `json:"exclusiveMinimum,omitempty"`
+	Format           string                `json:"format,omitempty"`
+	Items            *JSONSchema           `json:"items,omitempty"`
+	Maximum          *float64              `json:"maximum,omitempty"`
+	MaxItems         int                   `json:"maxItems,omitempty"`
+	MaxLength        int                   `json:"maxLength,omitempty"`
+	MinItems         int                   `json:"minItems,omitempty"`
+	MinLength        int                   `json:"minLength,omitempty"`
+	Minimum          *float64              `json:"minimum,omitempty"`
+	Not              *JSONSchema           `json:"not,omitempty"`
+	OneOf            []JSONSchema          `json:"oneOf,omitempty"`
+	Pattern          string                `json:"pattern,omitempty"`
+	Properties       map[string]JSONSchema `json:"properties,omitempty"`
+	Ref              string                `json:"$ref,omitempty"`
+	Required         []string              `json:"required,omitempty"`
+	Title            string                `json:"title,omitempty"`
+	// Type is either a single type name or, since OpenAPI 3.1, an array of type names.
+	Type any `json:"type,omitempty"`
+}
+
+// Types returns the type names of the schema whether the type keyword is a single name or an array.
+func (s JSONSchema) Types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		if t == "" {
+			return nil
+		}
+		return []string{t}
+	case []string:
+		return t
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if name, ok := v.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+// HasType reports whether the schema allows the given type name.
+func (s JSONSchema) HasType(name string) bool {
+	for _, t := range s.Types() {
+		if t == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AdditionalPropertiesSchema returns the schema of the additional properties of an object schema,
+// nil if any value is allowed, and whether the additional properties are allowed at all.
+func (s JSONSchema) AdditionalPropertiesSchema() (*JSONSchema, bool) {
+	switch ap := s.AdditionalProperties.(type) {
+	case nil:
+		return nil, true
+	case bool:
+		return nil, ap
+	case JSONSchema:
+		return &ap, true
+	case *JSONSchema:
+		return ap, true
+	default:
+		raw, err := json.Marshal(ap)
+		if err != nil {
+			return nil, true
+		}
+		var schema JSONSchema
+		if err = json.Unmarshal(raw, &schema); err != nil {
+			return nil, true
+		}
+		return &schema, true
+	}
+}
+
+// exclusiveMinimum returns the exclusive minimum of the schema, nil if it has none:
+// the number of the exclusiveMinimum keyword, or the minimum made exclusive in OpenAPI 3.0.
+func (s JSONSchema) exclusiveMinimum() *float64 {
+	return exclusiveBound(s.ExclusiveMinimum, s.Minimum)
+}
+
+// exclusiveMaximum returns the exclusive maximum of the schema, nil if it has none, as exclusiveMinimum does.
+func (s JSONSchema) exclusiveMaximum() *float64 {
+	return exclusiveBound(s.ExclusiveMaximum, s.Maximum)
+}
+
+func exclusiveBound(exclusive any, bound *float64) *float64 {
+	switch v := exclusive.(type) {
+	case float64:
+		return &v
+	case int:
+		f := float64(v)
+		return &f
+	case bool:
+		if v {
+			return bound
+		}
+	}
+
+	return nil
+}
+
+// itemsSchema returns the schema of the items of an array schema, an empty schema if it has none.
+func (s JSONSchema) itemsSchema() JSONSchema {
+	if s.Items == nil {
+		return JSONSchema{}
+	}
+
+	return *s.Items
 }
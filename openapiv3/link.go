@@ -115,4 +115,6 @@ type Link struct {
 	Description string `json:"description,omitempty"`
 	// A server object to be used by the target operation.
 	Server *Server `json:"server,omitempty"`
+	// Extensions are the Specification Extensions of the link.
+	Extensions Extensions `json:"-"`
 }
@@ -0,0 +1,319 @@
+package openapiv3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// LinkRequest is the concrete request designated by a Link once evaluated against an exchange.
+type LinkRequest struct {
+	// Operation is the target operation of the link.
+	Operation PathOperation
+	// Server is the server URL defined by the link, empty to use the server of the exchange.
+	Server string
+	// Path is the path of the target operation with its path parameters replaced by their values.
+	Path       string
+	PathParams map[string]string
+	Query      url.Values
+	Header     http.Header
+	Cookies    map[string]string
+	// Body is the request body defined by the link, nil if there is none.
+	Body any
+}
+
+// NewRequest returns the HTTP request to follow the link.
+// The base URL is used when the link does not define a server. The body, if any, is encoded as JSON.
+func (lr *LinkRequest) NewRequest(ctx context.Context, baseURL string) (*http.Request, error) {
+	server := lr.Server
+	if server == "" {
+		server = baseURL
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(server, "/") + lr.Path)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+	u.RawQuery = lr.Query.Encode()
+
+	var body io.Reader
+	if lr.Body != nil {
+		raw, err := json.Marshal(lr.Body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, lr.Operation.Method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	for name, values := range lr.Header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, name := range sortedKeys(lr.Cookies) {
+		req.AddCookie(&http.Cookie{Name: name, Value: lr.Cookies[name]})
+	}
+
+	return req, nil
+}
+
+// EvaluateLinks evaluates the links of the response returned by the operation of the given path and method.
+// The response is selected by the status code of the exchange: the exact code first, then the range (e.g. 2XX),
+// then the default response.
+func (o *OpenAPI) EvaluateLinks(path, method string, exchange *Exchange) (map[string]*LinkRequest, error) {
+	pathItem, ok := o.Paths[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown path %q", path)
+	}
+
+	pathItem, err := o.ResolvePathItem(pathItem)
+	if err != nil {
+		return nil, fmt.Errorf("path %q: %w", path, err)
+	}
+
+	op := pathItem.GetOperation(method)
+	if op == nil || op.Responses == nil {
+		return nil, fmt.Errorf("unknown operation %s %q", method, path)
+	}
+	if exchange.Response == nil {
+		return nil, fmt.Errorf("%s %q: missing response", method, path)
+	}
+
+	response, ok := responseForStatus(*op.Responses, exchange.Response.StatusCode)
+	if !ok {
+		return nil, nil
+	}
+
+	response, err = o.ResolveResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", method, path, err)
+	}
+
+	requests := make(map[string]*LinkRequest, len(response.Links))
+	for _, name := range sortedKeys(response.Links) {
+		lr, err := o.EvaluateLink(response.Links[name], exchange)
+		if err != nil {
+			return nil, fmt.Errorf("link %q: %w", name, err)
+		}
+		requests[name] = lr
+	}
+
+	return requests, nil
+}
+
+// responseForStatus returns the response matching a status code: the exact code, the range, or the default one.
+func responseForStatus(responses Responses, status int) (Response, bool) {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", "default"} {
+		if response, ok := responses[key]; ok {
+			return response, true
+		}
+	}
+
+	return Response{}, false
+}
+
+// EvaluateLink computes the request designated by a link from the exchange it has been returned with.
+// The parameter values and the request body which are runtime expressions, or embed some in curly braces,
+// are evaluated; the other values are constants.
+func (o *OpenAPI) EvaluateLink(link Link, exchange *Exchange) (*LinkRequest, error) {
+	link, err := o.ResolveLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := o.linkTarget(link)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := o.operationParameters(target)
+	if err != nil {
+		return nil, err
+	}
+
+	lr := &LinkRequest{
+		Operation:  target,
+		PathParams: map[string]string{},
+		Query:      url.Values{},
+		Header:     http.Header{},
+		Cookies:    map[string]string{},
+	}
+	if link.Server != nil {
+		lr.Server = link.Server.URL
+	}
+
+	for _, key := range sortedKeys(link.Parameters) {
+		in, name, err := linkParameterLocation(key, parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := evaluateExpressionValue(link.Parameters[key], exchange)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", key, err)
+		}
+
+		s, err := formatValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", key, err)
+		}
+
+		switch in {
+		case "path":
+			lr.PathParams[name] = s
+		case "query":
+			lr.Query.Add(name, s)
+		case "header":
+			lr.Header.Add(name, s)
+		case "cookie":
+			lr.Cookies[name] = s
+		}
+	}
+
+	if link.RequestBody != nil {
+		lr.Body, err = evaluateExpressionValue(link.RequestBody, exchange)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+	}
+
+	lr.Path, err = expandPath(target.Path, lr.PathParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return lr, nil
+}
+
+// linkTarget returns the operation designated by the operationId or the operationRef of a link.
+func (o *OpenAPI) linkTarget(link Link) (PathOperation, error) {
+	if link.OperationID != "" {
+		po, ok := o.OperationByID(link.OperationID)
+		if !ok {
+			return PathOperation{}, fmt.Errorf("unknown operationId %q", link.OperationID)
+		}
+		return po, nil
+	}
+
+	if !strings.HasPrefix(link.OperationRef, "#") {
+		return PathOperation{}, fmt.Errorf("unsupported operationRef %q", link.OperationRef)
+	}
+
+	ptr := strings.TrimPrefix(link.OperationRef, "#")
+	for _, po := range o.Operations() {
+		if po.Pointer == ptr {
+			return po, nil
+		}
+	}
+
+	return PathOperation{}, fmt.Errorf("unknown operationRef %q", link.OperationRef)
+}
+
+// operationParameters returns the parameters of an operation, with the ones of its path item for the paths.
+func (o *OpenAPI) operationParameters(po PathOperation) ([]Parameter, error) {
+	if strings.HasPrefix(po.Pointer, "/paths/") && strings.Count(po.Pointer, "/") == 3 {
+		ep, err := o.EffectiveParameters(po.Path, po.Method)
+		if err != nil {
+			return nil, err
+		}
+		return ep.All(), nil
+	}
+
+	parameters := make([]Parameter, 0, len(po.Operation.Parameters))
+	for _, p := range po.Operation.Parameters {
+		resolved, err := o.ResolveParameter(p)
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, resolved)
+	}
+
+	return parameters, nil
+}
+
+// linkParameterLocation returns the location and the name of a link parameter key,
+// which can be qualified by the parameter location (e.g. path.id).
+func linkParameterLocation(key string, parameters []Parameter) (string, string, error) {
+	if in, name, ok := strings.Cut(key, "."); ok {
+		switch in {
+		case "path", "query", "header", "cookie":
+			return in, name, nil
+		}
+	}
+
+	var locations []string
+	for _, p := range parameters {
+		if p.Name == key {
+			locations = append(locations, p.In)
+		}
+	}
+
+	switch len(locations) {
+	case 0:
+		return "", "", fmt.Errorf("unknown parameter %q", key)
+	case 1:
+		return locations[0], key, nil
+	default:
+		return "", "", fmt.Errorf("ambiguous parameter %q, qualify it with its location", key)
+	}
+}
+
+// evaluateExpressionValue returns the value of a string holding a runtime expression or a template embedding some,
+// or the value itself if it holds none.
+func evaluateExpressionValue(value any, exchange *Exchange) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if strings.HasPrefix(s, "$") {
+		expr, err := ParseRuntimeExpression(s)
+		if err != nil {
+			return nil, err
+		}
+		return exchange.Evaluate(expr)
+	}
+
+	if strings.Contains(s, "{$") {
+		t, err := ParseExpressionTemplate(s)
+		if err != nil {
+			return nil, err
+		}
+		return exchange.Expand(t)
+	}
+
+	return s, nil
+}
+
+// expandPath replaces the parameters of a path template by their escaped values.
+func expandPath(template string, params map[string]string) (string, error) {
+	var missing []string
+	path := pathTemplateParam.ReplaceAllStringFunc(template, func(param string) string {
+		name := param[1 : len(param)-1]
+		value, ok := params[name]
+		if !ok {
+			missing = append(missing, name)
+			return param
+		}
+		return url.PathEscape(value)
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing path parameters %s", strings.Join(missing, ", "))
+	}
+
+	return path, nil
+}
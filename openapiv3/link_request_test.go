@@ -0,0 +1,194 @@
+package openapiv3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_EvaluateLinks(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/users": PathItem{
+				Post: &Operation{
+					OperationID: "createUser",
+					Responses: &Responses{
+						"201": {
+							Description: "created",
+							Links: map[string]Link{
+								"self":    {Reference: Reference{Ref: "#/components/links/self"}},
+								"address": {OperationRef: "#/paths/~1users~1{userId}~1address/put", Parameters: map[string]any{"path.userId": "$response.body#/id", "verbose": true}, RequestBody: "$request.body#/address"},
+								"search":  {OperationID: "searchUsers", Parameters: map[string]any{"q": "name:{$request.body#/name}", "X-Trace": "$request.header.X-Trace"}, Server: &Server{URL: "https://search.example.com"}},
+							},
+						},
+						"4XX": {
+							Description: "error",
+							Links:       map[string]Link{"unknown": {OperationID: "unknown"}},
+						},
+					},
+				},
+			},
+			"/users/{userId}": PathItem{
+				Parameters: []Parameter{{Name: "userId", In: "path", Required: boolPtr(true)}},
+				Get:        &Operation{OperationID: "getUser"},
+			},
+			"/users/{userId}/address": PathItem{
+				Put: &Operation{Parameters: []Parameter{
+					{Name: "userId", In: "path", Required: boolPtr(true)},
+					{Name: "verbose", In: "query"},
+				}},
+			},
+			"/search": PathItem{
+				Get: &Operation{
+					OperationID: "searchUsers",
+					Parameters: []Parameter{
+						{Name: "q", In: "query"},
+						{Name: "X-Trace", In: "header"},
+					},
+				},
+			},
+		},
+		Components: &Components{
+			Links: map[string]Link{
+				"self": {OperationID: "getUser", Parameters: map[string]any{"userId": "$response.body#/id"}},
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/users", nil)
+	req.Header.Set("X-Trace", "trace-1")
+	exchange := &Exchange{
+		Request:      req,
+		RequestBody:  []byte(`{"name":"Bob","address":{"city":"Lyon"}}`),
+		Response:     &http.Response{StatusCode: http.StatusCreated},
+		ResponseBody: []byte(`{"id":"u/1"}`),
+	}
+
+	requests, err := oas.EvaluateLinks("/users", http.MethodPost, exchange)
+	require.NoError(t, err)
+	require.Len(t, requests, 3)
+
+	self := requests["self"]
+	assert.Equal(t, "getUser", self.Operation.Operation.OperationID)
+	assert.Equal(t, "/users/u%2F1", self.Path)
+	assert.Equal(t, map[string]string{"userId": "u/1"}, self.PathParams)
+	assert.Nil(t, self.Body)
+
+	address := requests["address"]
+	assert.Equal(t, http.MethodPut, address.Operation.Method)
+	assert.Equal(t, "/users/u%2F1/address", address.Path)
+	assert.Equal(t, url.Values{"verbose": []string{"true"}}, address.Query)
+	assert.Equal(t, map[string]any{"city": "Lyon"}, address.Body)
+
+	search := requests["search"]
+	assert.Equal(t, "https://search.example.com", search.Server)
+	assert.Equal(t, "/search", search.Path)
+	assert.Equal(t, url.Values{"q": []string{"name:Bob"}}, search.Query)
+	assert.Equal(t, "trace-1", search.Header.Get("X-Trace"))
+
+	exchange.Response.StatusCode = http.StatusNotFound
+	_, err = oas.EvaluateLinks("/users", http.MethodPost, exchange)
+	assert.EqualError(t, err, `link "unknown": unknown operationId "unknown"`)
+
+	exchange.Response.StatusCode = http.StatusInternalServerError
+	requests, err = oas.EvaluateLinks("/users", http.MethodPost, exchange)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func TestOpenAPI_EvaluateLink_errors(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/items/{id}": PathItem{
+				Get: &Operation{
+					OperationID: "getItem",
+					Parameters: []Parameter{
+						{Name: "id", In: "path", Required: boolPtr(true)},
+						{Name: "id", In: "query"},
+					},
+				},
+			},
+		},
+	}
+	exchange := &Exchange{Response: &http.Response{StatusCode: http.StatusOK}, ResponseBody: []byte(`{"id":1}`)}
+
+	tests := []struct {
+		desc     string
+		link     Link
+		expected string
+	}{
+		{
+			desc:     "unknown operationRef",
+			link:     Link{OperationRef: "#/paths/~1unknown/get"},
+			expected: `unknown operationRef "#/paths/~1unknown/get"`,
+		},
+		{
+			desc:     "remote operationRef",
+			link:     Link{OperationRef: "https://example.com/openapi.json#/paths/~1items/get"},
+			expected: `unsupported operationRef "https://example.com/openapi.json#/paths/~1items/get"`,
+		},
+		{
+			desc:     "ambiguous parameter",
+			link:     Link{OperationID: "getItem", Parameters: map[string]any{"id": 1}},
+			expected: `ambiguous parameter "id", qualify it with its location`,
+		},
+		{
+			desc:     "unknown parameter",
+			link:     Link{OperationID: "getItem", Parameters: map[string]any{"other": 1}},
+			expected: `unknown parameter "other"`,
+		},
+		{
+			desc:     "missing path parameter",
+			link:     Link{OperationID: "getItem", Parameters: map[string]any{"query.id": 1}},
+			expected: "missing path parameters id",
+		},
+		{
+			desc:     "undefined value",
+			link:     Link{OperationID: "getItem", Parameters: map[string]any{"path.id": "$response.body#/unknown"}},
+			expected: `parameter "path.id": undefined value`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := oas.EvaluateLink(test.link, exchange)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestLinkRequest_NewRequest(t *testing.T) {
+	lr := &LinkRequest{
+		Operation: PathOperation{MethodOperation: MethodOperation{Method: http.MethodPut}},
+		Path:      "/users/1/address",
+		Query:     url.Values{"verbose": []string{"true"}},
+		Header:    http.Header{"X-Trace": []string{"trace-1"}},
+		Cookies:   map[string]string{"session": "s1"},
+		Body:      map[string]any{"city": "Lyon"},
+	}
+
+	req, err := lr.NewRequest(context.Background(), "https://api.example.com/v1/")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "https://api.example.com/v1/users/1/address?verbose=true", req.URL.String())
+	assert.Equal(t, "trace-1", req.Header.Get("X-Trace"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	cookie, err := req.Cookie("session")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", cookie.Value)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"city":"Lyon"}`, string(body))
+}
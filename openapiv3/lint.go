@@ -0,0 +1,232 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// LintIgnoreExtension is the extension suppressing the findings of lint rules on a node and its children.
+// Its value is a rule ID, a list of rule IDs, or true to suppress the findings of all the rules.
+const LintIgnoreExtension = "x-lint-ignore"
+
+// Severity is the severity of a lint finding.
+type Severity int
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"off", "info", "warning", "error"}
+
+// String returns the name of the severity: off, info, warning or error.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("unknown severity %d", int(s))
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name, warn being accepted for warning.
+func (s *Severity) UnmarshalText(text []byte) error {
+	name := strings.ToLower(string(text))
+	if name == "warn" {
+		name = "warning"
+	}
+
+	for i, severityName := range severityNames {
+		if name == severityName {
+			*s = Severity(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// UnmarshalJSON decodes a severity from its name, or from false for off, as YAML 1.1 decodes off as a boolean.
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" {
+		*s = SeverityOff
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("decode severity: %w", err)
+	}
+
+	return s.UnmarshalText([]byte(name))
+}
+
+// LintRule is a rule checked by a Linter.
+type LintRule struct {
+	// ID identifies the rule in the findings, the configuration and the x-lint-ignore extensions,
+	// e.g. operation-summary.
+	ID string
+	// Description describes what the rule checks.
+	Description string
+	// Severity is the default severity of the findings of the rule, SeverityOff for a rule disabled by default.
+	Severity Severity
+	// Check calls report for each problem of the document, with the JSON Pointer of the node at fault.
+	Check func(doc *OpenAPI, report func(ptr, message string))
+}
+
+// LintFinding is a problem of a document found by a lint rule.
+type LintFinding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Pointer is the JSON Pointer of the node at fault.
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+// String returns the finding as "severity pointer: message (rule)".
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", f.Severity, f.Pointer, f.Message, f.Rule)
+}
+
+// LintConfig configures the rules of a Linter.
+type LintConfig struct {
+	// Rules overrides the severity of rules by ID, off disabling a rule.
+	Rules map[string]Severity `json:"rules,omitempty"`
+	// CustomRules declares rules checked in addition to the built-in ones.
+	CustomRules []CustomLintRule `json:"customRules,omitempty"`
+}
+
+// Linter checks the style of OpenAPI documents with rules, regardless of their validity.
+type Linter struct {
+	rules []LintRule
+}
+
+// NewLinter returns a Linter checking the built-in rules, see BuiltinLintRules, the custom rules of the configuration
+// and the given ones, with their severities overridden by the configuration.
+func NewLinter(config LintConfig, rules ...LintRule) (*Linter, error) {
+	l := &Linter{}
+
+	all := BuiltinLintRules()
+	for _, custom := range config.CustomRules {
+		rule, err := custom.LintRule()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rule)
+	}
+
+	ids := map[string]bool{}
+	for _, rule := range append(all, rules...) {
+		switch {
+		case rule.ID == "":
+			return nil, errors.New("rule without ID")
+		case ids[rule.ID]:
+			return nil, fmt.Errorf("rule %q already defined", rule.ID)
+		case rule.Check == nil:
+			return nil, fmt.Errorf("rule %q has no check", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		if severity, ok := config.Rules[rule.ID]; ok {
+			rule.Severity = severity
+		}
+		l.rules = append(l.rules, rule)
+	}
+
+	for _, id := range sortedKeys(config.Rules) {
+		if !ids[id] {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+	}
+
+	return l, nil
+}
+
+// Rules returns the rules of the linter, with their configured severities.
+func (l *Linter) Rules() []LintRule {
+	return append([]LintRule(nil), l.rules...)
+}
+
+// Lint returns the findings of the enabled rules on a document, sorted by pointer then rule.
+// The findings on a node, or on its children, are suppressed by its x-lint-ignore extension.
+func (l *Linter) Lint(doc *OpenAPI) []LintFinding {
+	var findings []LintFinding
+	for _, rule := range l.rules {
+		if rule.Severity == SeverityOff {
+			continue
+		}
+
+		rule.Check(doc, func(ptr, message string) {
+			if !lintIgnored(doc, ptr, rule.ID) {
+				findings = append(findings, LintFinding{Rule: rule.ID, Severity: rule.Severity, Pointer: ptr, Message: message})
+			}
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Pointer != findings[j].Pointer {
+			return findings[i].Pointer < findings[j].Pointer
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+
+	return findings
+}
+
+// lintIgnored reports whether the node at a pointer, or one of its ancestors, ignores a rule.
+func lintIgnored(doc *OpenAPI, ptr, rule string) bool {
+	tokens := splitPointer(ptr)
+	for i := len(tokens); i >= 0; i-- {
+		node, err := doc.Lookup(Pointer(tokens[:i]).String())
+		if err != nil {
+			continue
+		}
+
+		extensions := extensionsOf(node)
+		if ignore, ok := extensions[LintIgnoreExtension].(bool); ok && ignore {
+			return true
+		}
+		if containsString(extensions.Strings(LintIgnoreExtension), rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extensionsOf returns the extensions of a node of a document, nil if it is not extensible.
+func extensionsOf(node any) Extensions {
+	v := reflect.ValueOf(node)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := v.FieldByName("Extensions")
+	if !field.IsValid() {
+		return nil
+	}
+	extensions, _ := field.Interface().(Extensions)
+
+	return extensions
+}
@@ -0,0 +1,413 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CustomLintRule is a lint rule declared in a configuration rather than coded in Go:
+// checks applied to a field of the nodes of the document matched by a selector, e.g.
+//
+//	id: operation-id-camel-case
+//	given: $.paths.*.*
+//	field: operationId
+//	pattern: ^[a-z][a-zA-Z0-9]*$
+type CustomLintRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// Severity is the default severity of the findings of the rule, warning if not set.
+	Severity *Severity `json:"severity,omitempty"`
+	// Message replaces the messages of the findings, describing the failed check by default.
+	Message string `json:"message,omitempty"`
+
+	// Given is a JSONPath-like selector of the objects of the document visited by a Walker:
+	// $ for the document, followed by .name or ['name'] for a child, .* or [*] for any child,
+	// and ..name or ..* for a descendant at any depth, e.g. $.paths.*.*, $..parameters[*] or $.components.schemas.*.
+	// The objects holding a reference are not selected: the referenced components are checked where they are defined.
+	Given string `json:"given"`
+	// Field is the path of the checked value relative to the selected objects, e.g. operationId,
+	// schema.type or content['application/json'].schema, @key for the last key of their pointer,
+	// and the objects themselves when empty.
+	// A field at its zero value is considered missing, as it is omitted from the document.
+	Field string `json:"field,omitempty"`
+
+	// Truthy checks that the field is present and not false, empty or null.
+	Truthy bool `json:"truthy,omitempty"`
+	// Pattern checks that the field is a string matching the regular expression.
+	Pattern string `json:"pattern,omitempty"`
+	// Enum checks that the field is one of the values.
+	Enum []any `json:"enum,omitempty"`
+	// Length checks the length of a string, an array or an object.
+	Length *LintLength `json:"length,omitempty"`
+	// Schema checks that the field is valid against the schema, see OpenAPI.ValidateValue.
+	Schema *JSONSchema `json:"schema,omitempty"`
+}
+
+// LintLength bounds the length of a string, in characters, of an array, or of an object, in properties.
+type LintLength struct {
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
+}
+
+// LintConfigFromFile loads a LintConfig from a JSON or YAML file.
+func LintConfigFromFile(path string) (LintConfig, error) {
+	var config LintConfig
+
+	content, err := readFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	if err = json.Unmarshal(content, &config); err != nil {
+		return config, fmt.Errorf("unmarshal lint config: %w", err)
+	}
+
+	return config, nil
+}
+
+// LintRule compiles the custom rule into a LintRule.
+func (r CustomLintRule) LintRule() (LintRule, error) {
+	if r.ID == "" {
+		return LintRule{}, errors.New("custom rule without ID")
+	}
+
+	rule, err := r.compile()
+	if err != nil {
+		return LintRule{}, fmt.Errorf("custom rule %q: %w", r.ID, err)
+	}
+
+	return rule, nil
+}
+
+func (r CustomLintRule) compile() (LintRule, error) {
+	given, err := parseLintPath(r.Given, true)
+	if err != nil {
+		return LintRule{}, fmt.Errorf("given: %w", err)
+	}
+
+	var field []lintPathStep
+	if r.Field != "" && r.Field != "@key" {
+		if field, err = parseLintPath(r.Field, false); err != nil {
+			return LintRule{}, fmt.Errorf("field: %w", err)
+		}
+	}
+
+	var pattern *regexp.Regexp
+	if r.Pattern != "" {
+		if pattern, err = regexp.Compile(r.Pattern); err != nil {
+			return LintRule{}, fmt.Errorf("pattern: %w", err)
+		}
+	}
+
+	if r.Length != nil && r.Length.Min != nil && r.Length.Max != nil && *r.Length.Min > *r.Length.Max {
+		return LintRule{}, fmt.Errorf("length: min %d greater than max %d", *r.Length.Min, *r.Length.Max)
+	}
+
+	if !r.Truthy && pattern == nil && r.Enum == nil && r.Length == nil && r.Schema == nil {
+		return LintRule{}, errors.New("no check: one of truthy, pattern, enum, length or schema is required")
+	}
+
+	severity := SeverityWarning
+	if r.Severity != nil {
+		severity = *r.Severity
+	}
+
+	name := r.Field
+	if name == "" {
+		name = "value"
+	}
+
+	return LintRule{
+		ID:          r.ID,
+		Description: r.Description,
+		Severity:    severity,
+		Check: func(doc *OpenAPI, report func(ptr, message string)) {
+			fail := func(ptr, message string) {
+				if r.Message != "" {
+					message = r.Message
+				}
+				report(ptr, message)
+			}
+
+			w := &Walker{
+				Enter: func(node *WalkNode) error {
+					tokens := splitPointer(node.Pointer)
+					if refOf(node.Value) != "" || !matchLintPath(given, tokens) {
+						return nil
+					}
+
+					var value any
+					var ok bool
+					if r.Field == "@key" {
+						if len(tokens) > 0 {
+							value, ok = tokens[len(tokens)-1], true
+						}
+					} else {
+						value, ok = lintField(node.Value, field)
+					}
+
+					for _, message := range r.check(doc, name, value, ok, pattern) {
+						fail(node.Pointer, message)
+					}
+
+					return nil
+				},
+			}
+			// The hook does not fail.
+			_ = w.Walk(doc)
+		},
+	}, nil
+}
+
+// check returns the messages of the failed checks of a field, ok reporting whether it is present.
+func (r CustomLintRule) check(doc *OpenAPI, name string, value any, ok bool, pattern *regexp.Regexp) []string {
+	if !ok {
+		if r.Truthy {
+			return []string{fmt.Sprintf("%s is missing", name)}
+		}
+		// The other checks apply to the present fields only.
+		return nil
+	}
+
+	var messages []string
+	if r.Truthy && !truthy(value) {
+		messages = append(messages, fmt.Sprintf("%s is empty", name))
+	}
+
+	if pattern != nil {
+		if s, isString := value.(string); !isString {
+			messages = append(messages, fmt.Sprintf("%s is not a string", name))
+		} else if !pattern.MatchString(s) {
+			messages = append(messages, fmt.Sprintf("%s %q does not match %q", name, s, r.Pattern))
+		}
+	}
+
+	if r.Enum != nil && !containsValue(r.Enum, value) {
+		messages = append(messages, fmt.Sprintf("%s %s is not one of %s", name, compactJSON(value), compactJSON(r.Enum)))
+	}
+
+	if r.Length != nil {
+		if length, hasLength := lengthOf(value); !hasLength {
+			messages = append(messages, fmt.Sprintf("%s has no length", name))
+		} else if r.Length.Min != nil && length < *r.Length.Min {
+			messages = append(messages, fmt.Sprintf("%s is shorter than %d", name, *r.Length.Min))
+		} else if r.Length.Max != nil && length > *r.Length.Max {
+			messages = append(messages, fmt.Sprintf("%s is longer than %d", name, *r.Length.Max))
+		}
+	}
+
+	if r.Schema != nil {
+		if err := doc.ValidateValue(*r.Schema, value); err != nil {
+			messages = append(messages, fmt.Sprintf("%s does not match the schema: %s", name, strings.ReplaceAll(err.Error(), "\n", "; ")))
+		}
+	}
+
+	return messages
+}
+
+// lintPathStep is a step of a selector or a field path.
+type lintPathStep struct {
+	name string
+	// wildcard matches any name.
+	wildcard bool
+	// descendant matches at any depth.
+	descendant bool
+}
+
+// parseLintPath parses a selector, starting with $ and accepting wildcards and descendants, or a field path.
+func parseLintPath(path string, selector bool) ([]lintPathStep, error) {
+	rest := path
+	if selector {
+		if !strings.HasPrefix(rest, "$") {
+			return nil, fmt.Errorf("%q does not start with $", path)
+		}
+		rest = rest[1:]
+	} else if rest != "" && rest[0] != '[' {
+		// The field path starts with a name.
+		rest = "." + rest
+	}
+
+	var steps []lintPathStep
+	for rest != "" {
+		var step lintPathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.descendant = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(rest, "."):
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			step.name, rest = rest[:end], rest[end:]
+			if step.name == "" {
+				return nil, fmt.Errorf("%q: empty name", path)
+			}
+			step.wildcard = step.name == "*"
+			steps = append(steps, step)
+			continue
+		case !strings.HasPrefix(rest, "["):
+			return nil, fmt.Errorf("%q: unexpected %q", path, rest)
+		}
+
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("%q: unterminated bracket", path)
+		}
+		name := rest[1:end]
+		rest = rest[end+1:]
+
+		switch {
+		case name == "*":
+			step.wildcard = true
+		case len(name) >= 2 && (name[0] == '\'' || name[0] == '"') && name[len(name)-1] == name[0]:
+			step.name = name[1 : len(name)-1]
+		default:
+			if _, err := strconv.Atoi(name); err != nil {
+				return nil, fmt.Errorf("%q: invalid bracket [%s]", path, name)
+			}
+			step.name = name
+		}
+		steps = append(steps, step)
+	}
+
+	if !selector {
+		for _, step := range steps {
+			if step.wildcard || step.descendant {
+				return nil, fmt.Errorf("%q: wildcards and descendants are not supported", path)
+			}
+		}
+	}
+
+	return steps, nil
+}
+
+// matchLintPath reports whether the tokens of a pointer match a selector.
+func matchLintPath(steps []lintPathStep, tokens []string) bool {
+	if len(steps) == 0 {
+		return len(tokens) == 0
+	}
+
+	step := steps[0]
+	for i := range tokens {
+		if i > 0 && !step.descendant {
+			break
+		}
+		if (step.wildcard || tokens[i] == step.name) && matchLintPath(steps[1:], tokens[i+1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lintField returns the value of a field of a node, as decoded from JSON, reporting whether it is present.
+func lintField(node any, field []lintPathStep) (any, bool) {
+	v := reflect.ValueOf(node)
+	for _, step := range field {
+		var err error
+		if v, err = pointerChild(v, step.name); err != nil {
+			return nil, false
+		}
+	}
+
+	if v.IsZero() {
+		return nil, false
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, false
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// refOf returns the reference held by a node of a document, empty if it holds none.
+func refOf(node any) string {
+	v := reflect.ValueOf(node)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := v.FieldByName("Ref")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
+
+// truthy reports whether a JSON value is not false, empty or null.
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// lengthOf returns the length of a JSON string, array or object.
+func lengthOf(value any) (int, bool) {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v), true
+	case []any:
+		return len(v), true
+	case map[string]any:
+		return len(v), true
+	default:
+		return 0, false
+	}
+}
+
+// containsValue reports whether a list holds a JSON value.
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compactJSON returns the JSON encoding of a value, for the messages.
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,125 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfigFromFile(t *testing.T) {
+	oas, err := FromFile("testdata/lint.yaml")
+	require.NoError(t, err)
+
+	config, err := LintConfigFromFile("testdata/lint_config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityOff, config.Rules["description"])
+
+	linter, err := NewLinter(config)
+	require.NoError(t, err)
+
+	var findings []string
+	for _, finding := range linter.Lint(oas) {
+		findings = append(findings, finding.String())
+	}
+
+	assert.Equal(t, []string{
+		`warning /components/schemas/Pet: description is shorter than 7 (schema-description-length)`,
+		`error /paths/~1health/get: operationId "health" does not match "^(list|get)[A-Z]" (operation-id-prefix)`,
+		`warning /paths/~1pets/get/responses/200/content/application~1json: schema.type does not match the schema: value array is not one of [object] (json-schema)`,
+		`warning /paths/~1pets~1{petId}~1: @key is longer than 10 (path-length)`,
+		`warning /paths/~1pets~1{petId}~1/get: Summarize the operation. (operation-summary-set)`,
+		`warning /paths/~1pets~1{petId}~1/parameters/0: in "path" is not one of ["query","header"] (parameter-in)`,
+	}, findings)
+}
+
+func TestCustomLintRule_LintRule(t *testing.T) {
+	tests := []struct {
+		desc          string
+		rule          string
+		expectedError string
+	}{
+		{
+			desc:          "without ID",
+			rule:          `{"given": "$.info", "field": "title", "truthy": true}`,
+			expectedError: "custom rule without ID",
+		},
+		{
+			desc:          "without check",
+			rule:          `{"id": "custom", "given": "$.info", "field": "title"}`,
+			expectedError: `custom rule "custom": no check: one of truthy, pattern, enum, length or schema is required`,
+		},
+		{
+			desc:          "selector without root",
+			rule:          `{"id": "custom", "given": "info", "truthy": true}`,
+			expectedError: `custom rule "custom": given: "info" does not start with $`,
+		},
+		{
+			desc:          "unterminated bracket",
+			rule:          `{"id": "custom", "given": "$.paths['/pets'", "truthy": true}`,
+			expectedError: `custom rule "custom": given: "$.paths['/pets'": unterminated bracket`,
+		},
+		{
+			desc:          "wildcard field",
+			rule:          `{"id": "custom", "given": "$.info", "field": "contact.*", "truthy": true}`,
+			expectedError: `custom rule "custom": field: "contact.*": wildcards and descendants are not supported`,
+		},
+		{
+			desc:          "invalid pattern",
+			rule:          `{"id": "custom", "given": "$.info", "field": "title", "pattern": "("}`,
+			expectedError: "custom rule \"custom\": pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			desc:          "invalid length",
+			rule:          `{"id": "custom", "given": "$.info", "field": "title", "length": {"min": 2, "max": 1}}`,
+			expectedError: `custom rule "custom": length: min 2 greater than max 1`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var rule CustomLintRule
+			require.NoError(t, json.Unmarshal([]byte(test.rule), &rule))
+
+			_, err := rule.LintRule()
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestMatchLintPath(t *testing.T) {
+	tests := []struct {
+		selector string
+		ptr      string
+		expected bool
+	}{
+		{selector: "$", ptr: "", expected: true},
+		{selector: "$", ptr: "/info", expected: false},
+		{selector: "$.info", ptr: "/info", expected: true},
+		{selector: "$.paths.*.*", ptr: "/paths/~1pets/get", expected: true},
+		{selector: "$.paths.*.*", ptr: "/paths/~1pets/get/parameters/0", expected: false},
+		{selector: "$.paths['/pets'].get", ptr: "/paths/~1pets/get", expected: true},
+		{selector: `$["paths"]["/pets"][*]`, ptr: "/paths/~1pets/post", expected: true},
+		{selector: "$..parameters[*]", ptr: "/paths/~1pets/get/parameters/0", expected: true},
+		{selector: "$..parameters[0]", ptr: "/components/parameters/Limit", expected: false},
+		{selector: "$..properties.*", ptr: "/components/schemas/Pet/properties/owner", expected: true},
+		{selector: "$..['application/json'].schema", ptr: "/paths/~1pets/get/responses/200/content/application~1json/schema", expected: true},
+		{selector: "$.components..*", ptr: "/components/schemas/Pet", expected: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.selector+" "+test.ptr, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := parseLintPath(test.selector, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, matchLintPath(steps, splitPointer(test.ptr)))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package openapiv3
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BuiltinLintRules returns the built-in rules of the linter:
+//   - operation-id: the operations have an operationId;
+//   - operation-summary: the operations have a summary;
+//   - operation-tags: the operations have tags, declared in the tags of the document;
+//   - path-kebab-case: the segments of the paths, except the template parameters, are kebab-case;
+//   - no-trailing-slash: the paths do not end with a slash;
+//   - error-response-schema: the 4xx responses have a content with a schema describing the error;
+//   - description: the info, the tags, the operations, the parameters and the component schemas have a description;
+//   - no-unused-components: the components are used by the paths, the webhooks or the security requirements.
+func BuiltinLintRules() []LintRule {
+	return []LintRule{
+		{
+			ID:          "operation-id",
+			Description: "The operations have an operationId.",
+			Severity:    SeverityWarning,
+			Check: func(doc *OpenAPI, report func(ptr, message string)) {
+				for _, po := range doc.Operations() {
+					if po.Operation.OperationID == "" {
+						report(po.Pointer, "operation has no operationId")
+					}
+				}
+			},
+		},
+		{
+			ID:          "operation-summary",
+			Description: "The operations have a summary.",
+			Severity:    SeverityWarning,
+			Check: func(doc *OpenAPI, report func(ptr, message string)) {
+				for _, po := range doc.Operations() {
+					if strings.TrimSpace(po.Operation.Summary) == "" {
+						report(po.Pointer, "operation has no summary")
+					}
+				}
+			},
+		},
+		{
+			ID:          "operation-tags",
+			Description: "The operations have tags, declared in the tags of the document.",
+			Severity:    SeverityWarning,
+			Check:       checkOperationTags,
+		},
+		{
+			ID:          "path-kebab-case",
+			Description: "The segments of the paths, except the template parameters, are kebab-case.",
+			Severity:    SeverityWarning,
+			Check:       checkPathKebabCase,
+		},
+		{
+			ID:          "no-trailing-slash",
+			Description: "The paths do not end with a slash.",
+			Severity:    SeverityWarning,
+			Check: func(doc *OpenAPI, report func(ptr, message string)) {
+				for _, path := range sortedKeys(doc.Paths) {
+					if path != "/" && strings.HasSuffix(path, "/") {
+						report(appendPointer("/paths", path), "path ends with a slash")
+					}
+				}
+			},
+		},
+		{
+			ID:          "error-response-schema",
+			Description: "The 4xx responses have a content with a schema describing the error.",
+			Severity:    SeverityWarning,
+			Check:       checkErrorResponseSchema,
+		},
+		{
+			ID:          "description",
+			Description: "The info, the tags, the operations, the parameters and the component schemas have a description.",
+			Severity:    SeverityInfo,
+			Check:       checkDescriptions,
+		},
+		{
+			ID:          "no-unused-components",
+			Description: "The components are used by the paths, the webhooks or the security requirements.",
+			Severity:    SeverityWarning,
+			Check: func(doc *OpenAPI, report func(ptr, message string)) {
+				for _, ref := range doc.UnusedComponents() {
+					report(strings.TrimPrefix(ref, "#"), "component is not used")
+				}
+			},
+		},
+	}
+}
+
+func checkOperationTags(doc *OpenAPI, report func(ptr, message string)) {
+	declared := map[string]bool{}
+	for _, tag := range doc.Tags {
+		declared[tag.Name] = true
+	}
+
+	for _, po := range doc.Operations() {
+		if len(po.Operation.Tags) == 0 {
+			report(po.Pointer, "operation has no tags")
+		}
+		for i, tag := range po.Operation.Tags {
+			if !declared[tag] {
+				report(appendPointer(po.Pointer, "tags", fmt.Sprint(i)), fmt.Sprintf("tag %q is not declared in the tags of the document", tag))
+			}
+		}
+	}
+}
+
+// kebabCase matches a kebab-case path segment.
+var kebabCase = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func checkPathKebabCase(doc *OpenAPI, report func(ptr, message string)) {
+paths:
+	for _, path := range sortedKeys(doc.Paths) {
+		for _, segment := range strings.Split(path, "/") {
+			// The template parameters follow the naming of the parameters: only the literal parts are checked,
+			// without the separators surrounding the parameters, like the dot of {name}.json.
+			for _, literal := range pathTemplateParam.Split(segment, -1) {
+				if literal = strings.Trim(literal, "-._"); literal != "" && !kebabCase.MatchString(literal) {
+					report(appendPointer("/paths", path), fmt.Sprintf("path segment %q is not kebab-case", segment))
+					continue paths
+				}
+			}
+		}
+	}
+}
+
+// isErrorStatus reports whether a response code is a 4xx status code or the 4XX range.
+func isErrorStatus(code string) bool {
+	if len(code) != 3 || code[0] != '4' {
+		return false
+	}
+	if strings.EqualFold(code, "4XX") {
+		return true
+	}
+	_, err := strconv.Atoi(code)
+
+	return err == nil
+}
+
+func checkErrorResponseSchema(doc *OpenAPI, report func(ptr, message string)) {
+	for _, po := range doc.Operations() {
+		if po.Operation.Responses == nil {
+			continue
+		}
+
+		for _, code := range sortedKeys(*po.Operation.Responses) {
+			if !isErrorStatus(code) {
+				continue
+			}
+
+			response, err := doc.ResolveResponse((*po.Operation.Responses)[code])
+			if err != nil {
+				// The unresolved references are a matter of validity rather than style.
+				continue
+			}
+
+			hasSchema := false
+			for _, mediaType := range response.Content {
+				hasSchema = hasSchema || mediaType.Schema != nil
+			}
+			if !hasSchema {
+				report(appendPointer(po.Pointer, "responses", code), "error response has no schema")
+			}
+		}
+	}
+}
+
+func checkDescriptions(doc *OpenAPI, report func(ptr, message string)) {
+	missing := func(description string) bool { return strings.TrimSpace(description) == "" }
+
+	if missing(doc.Info.Description) {
+		report("/info", "info has no description")
+	}
+
+	for i, tag := range doc.Tags {
+		if missing(tag.Description) {
+			report(appendPointer("/tags", fmt.Sprint(i)), fmt.Sprintf("tag %q has no description", tag.Name))
+		}
+	}
+
+	for _, path := range sortedKeys(doc.Paths) {
+		for i, p := range doc.Paths[path].Parameters {
+			if p.Ref == "" && missing(p.Description) {
+				report(appendPointer("/paths", path, "parameters", fmt.Sprint(i)), fmt.Sprintf("parameter %q has no description", p.Name))
+			}
+		}
+	}
+
+	for _, po := range doc.Operations() {
+		if missing(po.Operation.Description) {
+			report(po.Pointer, "operation has no description")
+		}
+		for i, p := range po.Operation.Parameters {
+			if p.Ref == "" && missing(p.Description) {
+				report(appendPointer(po.Pointer, "parameters", fmt.Sprint(i)), fmt.Sprintf("parameter %q has no description", p.Name))
+			}
+		}
+	}
+
+	components := doc.components()
+	for _, name := range sortedKeys(components.Parameters) {
+		if missing(components.Parameters[name].Description) {
+			report(appendPointer("/components/parameters", name), fmt.Sprintf("parameter %q has no description", components.Parameters[name].Name))
+		}
+	}
+	for _, name := range sortedKeys(components.Schemas) {
+		if missing(components.Schemas[name].Description) {
+			report(appendPointer("/components/schemas", name), "schema has no description")
+		}
+	}
+}
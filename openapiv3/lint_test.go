@@ -0,0 +1,134 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinter_Lint(t *testing.T) {
+	oas, err := FromFile("testdata/lint.yaml")
+	require.NoError(t, err)
+
+	linter, err := NewLinter(LintConfig{})
+	require.NoError(t, err)
+
+	var findings []string
+	for _, finding := range linter.Lint(oas) {
+		findings = append(findings, finding.String())
+	}
+
+	assert.Equal(t, []string{
+		`info /components/schemas/Unused: schema has no description (description)`,
+		`warning /components/schemas/Unused: component is not used (no-unused-components)`,
+		`warning /components/securitySchemes/apiKey: component is not used (no-unused-components)`,
+		`info /info: info has no description (description)`,
+		`warning /paths/~1petOwners/get/responses/4XX: error response has no schema (error-response-schema)`,
+		`warning /paths/~1pets/get/responses/404: error response has no schema (error-response-schema)`,
+		`warning /paths/~1pets~1{petId}~1: path ends with a slash (no-trailing-slash)`,
+		`warning /paths/~1pets~1{petId}~1/get: operation has no operationId (operation-id)`,
+		`warning /paths/~1pets~1{petId}~1/get: operation has no summary (operation-summary)`,
+		`warning /paths/~1pets~1{petId}~1/get/tags/0: tag "dogs" is not declared in the tags of the document (operation-tags)`,
+		`info /paths/~1pets~1{petId}~1/parameters/0: parameter "petId" has no description (description)`,
+	}, findings)
+}
+
+func TestLinter_Lint_config(t *testing.T) {
+	oas, err := FromFile("testdata/lint.yaml")
+	require.NoError(t, err)
+
+	var config LintConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"rules": {"description": "off", "no-trailing-slash": "error", "no-unused-components": "off",
+		"error-response-schema": "off", "operation-tags": "off", "no-todo": "warn"}}`), &config))
+
+	linter, err := NewLinter(config, LintRule{
+		ID:       "no-todo",
+		Severity: SeverityOff,
+		Check: func(doc *OpenAPI, report func(ptr, message string)) {
+			for _, po := range doc.Operations() {
+				if po.Operation.Summary == "Health" {
+					report(po.Pointer+"/summary", "summary is too short")
+				}
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []LintFinding{
+		{Rule: "no-todo", Severity: SeverityWarning, Pointer: "/paths/~1health/get/summary", Message: "summary is too short"},
+		{Rule: "no-trailing-slash", Severity: SeverityError, Pointer: "/paths/~1pets~1{petId}~1", Message: "path ends with a slash"},
+		{Rule: "operation-id", Severity: SeverityWarning, Pointer: "/paths/~1pets~1{petId}~1/get", Message: "operation has no operationId"},
+		{Rule: "operation-summary", Severity: SeverityWarning, Pointer: "/paths/~1pets~1{petId}~1/get", Message: "operation has no summary"},
+	}, linter.Lint(oas))
+}
+
+func TestNewLinter_errors(t *testing.T) {
+	check := func(doc *OpenAPI, report func(ptr, message string)) {}
+
+	tests := []struct {
+		desc          string
+		config        LintConfig
+		rules         []LintRule
+		expectedError string
+	}{
+		{
+			desc:          "unknown rule",
+			config:        LintConfig{Rules: map[string]Severity{"unknown": SeverityError}},
+			expectedError: `unknown rule "unknown"`,
+		},
+		{
+			desc:          "duplicated rule",
+			rules:         []LintRule{{ID: "operation-id", Check: check}},
+			expectedError: `rule "operation-id" already defined`,
+		},
+		{
+			desc:          "rule without ID",
+			rules:         []LintRule{{Check: check}},
+			expectedError: "rule without ID",
+		},
+		{
+			desc:          "rule without check",
+			rules:         []LintRule{{ID: "custom"}},
+			expectedError: `rule "custom" has no check`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewLinter(test.config, test.rules...)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestSeverity_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		text              string
+		expected          Severity
+		expectedAssertion assert.ErrorAssertionFunc
+	}{
+		{text: "off", expected: SeverityOff, expectedAssertion: assert.NoError},
+		{text: "info", expected: SeverityInfo, expectedAssertion: assert.NoError},
+		{text: "warn", expected: SeverityWarning, expectedAssertion: assert.NoError},
+		{text: "Warning", expected: SeverityWarning, expectedAssertion: assert.NoError},
+		{text: "error", expected: SeverityError, expectedAssertion: assert.NoError},
+		{text: "fatal", expectedAssertion: assert.Error},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.text, func(t *testing.T) {
+			t.Parallel()
+
+			var severity Severity
+			err := severity.UnmarshalText([]byte(test.text))
+			test.expectedAssertion(t, err)
+			assert.Equal(t, test.expected, severity)
+		})
+	}
+}
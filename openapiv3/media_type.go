@@ -63,6 +63,8 @@ type MediaType struct {
 	// The key, being the property name, MUST exist in the schema as a property.
 	// The encoding object SHALL only apply to requestBody objects when the media type is multipart or application/x-www-form-urlencoded.
 	Encoding map[string]Encoding `json:"encoding,omitempty"`
+	// Extensions are the Specification Extensions of the media type.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates a MediaType.
@@ -0,0 +1,442 @@
+package openapiv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// componentFields lists the fields of the Components Object in the order of the specification.
+var componentFields = []string{
+	"schemas", "responses", "parameters", "examples", "requestBodies",
+	"headers", "securitySchemes", "links", "callbacks", "pathItems",
+}
+
+// MergeSource is a document merged by Merge.
+type MergeSource struct {
+	// Name identifies the source in the conflicts and namespaces its conflicting components.
+	Name string
+	Doc  *OpenAPI
+	// PathPrefix is prepended to the paths of the document, e.g. /pets.
+	PathPrefix string
+	// Servers replace the servers of the document, e.g. with the internal address of the service behind the gateway.
+	Servers []Server
+}
+
+// MergeOptions configures Merge.
+type MergeOptions struct {
+	// Openapi is the version of the merged document, by default the version of the first source,
+	// or 3.1.0 without sources.
+	Openapi string
+	Info    Info
+	// Servers are the servers of the merged document, e.g. the API gateway.
+	Servers []Server
+}
+
+// MergeConflict is a part of a source document that Merge could not merge.
+type MergeConflict struct {
+	// Source is the name of the source.
+	Source string
+	// Pointer is the JSON Pointer of the node in the source document.
+	Pointer string
+	// Message explains the conflict.
+	Message string
+}
+
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Source, c.Pointer, c.Message)
+}
+
+// Merge merges the documents of the sources, in order, into a new document:
+//   - the paths are prefixed with the path prefix of their source;
+//   - the servers of a source, when they differ from the merged ones, and its security requirements
+//     are set on its operations having none;
+//   - a component already defined with the same name is kept once if the definitions are identical,
+//     otherwise the component of the source is renamed <name>_<component>, and the references to it updated;
+//   - the operations of a path defined by several sources are gathered in a single path item;
+//   - the tags are merged by name, in order of appearance, the first non-empty description being kept.
+//
+// The parts that cannot be merged, such as an operation, a webhook or a path item defined differently
+// by several sources, are kept from the first source and reported as conflicts,
+// as well as the duplicated operationIds.
+//
+// The sources must share the minor version of the merged document, e.g. 3.0, as the schemas of
+// OpenAPI 3.0 and 3.1 are written with different keywords: the other sources have to be converted first.
+func Merge(options MergeOptions, sources ...MergeSource) (*OpenAPI, []MergeConflict, error) {
+	names := map[string]bool{}
+	for _, source := range sources {
+		switch {
+		case source.Name == "":
+			return nil, nil, errors.New("source without name")
+		case names[source.Name]:
+			return nil, nil, fmt.Errorf("source %q already defined", source.Name)
+		case source.Doc == nil:
+			return nil, nil, fmt.Errorf("source %q: no document", source.Name)
+		}
+		names[source.Name] = true
+	}
+
+	version, err := mergedVersion(options.Openapi, sources)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &merger{
+		doc: &OpenAPI{
+			Openapi: version,
+			Info:    options.Info,
+			Servers: options.Servers,
+		},
+		operationIDs: map[string]string{},
+	}
+	for _, source := range sources {
+		if err := m.merge(source); err != nil {
+			return nil, nil, fmt.Errorf("source %q: %w", source.Name, err)
+		}
+	}
+
+	return m.doc, m.conflicts, nil
+}
+
+// mergedVersion returns the version of the merged document, checking that the sources share its minor version.
+func mergedVersion(version string, sources []MergeSource) (string, error) {
+	for _, source := range sources {
+		switch {
+		case source.Doc.Openapi == "":
+		case version == "":
+			version = source.Doc.Openapi
+		case minorVersion(source.Doc.Openapi) != minorVersion(version):
+			return "", fmt.Errorf("source %q: version %s cannot be merged into version %s", source.Name, source.Doc.Openapi, version)
+		}
+	}
+
+	if version == "" {
+		return "3.1.0", nil
+	}
+
+	return version, nil
+}
+
+// minorVersion returns the major and minor parts of a version, e.g. 3.1 for 3.1.0.
+func minorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+type merger struct {
+	doc       *OpenAPI
+	conflicts []MergeConflict
+	// operationIDs holds the pointers of the merged operations by operationId, prefixed by their source.
+	operationIDs map[string]string
+}
+
+func (m *merger) conflict(source MergeSource, ptr, format string, args ...any) {
+	m.conflicts = append(m.conflicts, MergeConflict{Source: source.Name, Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (m *merger) merge(source MergeSource) error {
+	doc, err := source.Doc.Clone()
+	if err != nil {
+		return err
+	}
+
+	localize(doc, source.Servers, m.doc.Servers)
+
+	if doc, err = m.namespace(source, doc); err != nil {
+		return err
+	}
+	m.components(source, doc)
+
+	for _, path := range sortedKeys(doc.Paths) {
+		prefixed := strings.TrimSuffix(source.PathPrefix, "/") + path
+		if m.doc.Paths == nil {
+			m.doc.Paths = Paths{}
+		}
+		m.pathItem(source, appendPointer("/paths", path), m.doc.Paths, prefixed, doc.Paths[path], doc)
+	}
+
+	for _, name := range sortedKeys(doc.Webhooks) {
+		if m.doc.Webhooks == nil {
+			m.doc.Webhooks = map[string]PathItem{}
+		}
+		m.pathItem(source, appendPointer("/webhooks", name), m.doc.Webhooks, name, doc.Webhooks[name], doc)
+	}
+
+	m.tags(doc.Tags)
+
+	return nil
+}
+
+// localize sets the servers and the security requirements of the document on its operations,
+// for them to apply once merged into a document having other servers and no security requirements.
+func localize(doc *OpenAPI, servers, mergedServers []Server) {
+	if servers == nil {
+		servers = doc.Servers
+	}
+	localServers := len(servers) > 0 && !sameJSON(servers, mergedServers)
+
+	for _, pathItems := range []map[string]PathItem{doc.Paths, doc.Webhooks, doc.components().PathItems} {
+		for _, pathItem := range pathItems {
+			for _, mo := range pathItem.Operations() {
+				if localServers && pathItem.Servers == nil && mo.Operation.Servers == nil {
+					mo.Operation.Servers = append([]Server(nil), servers...)
+				}
+				if len(doc.Security) > 0 && mo.Operation.Security == nil {
+					mo.Operation.Security = append([]SecurityRequirement(nil), doc.Security...)
+				}
+			}
+		}
+	}
+
+	doc.Servers = nil
+	doc.Security = nil
+}
+
+// namespace returns the document with the components conflicting with the merged ones renamed,
+// along with the references to them.
+// Renaming a component changes the components referencing it, so the conflicts are searched until none is found.
+func (m *merger) namespace(source MergeSource, original *OpenAPI) (*OpenAPI, error) {
+	renames := map[string]string{}
+	// renamedRefs holds the new references of the renamed components, already namespaced.
+	renamedRefs := map[string]bool{}
+	for {
+		doc, err := original.Clone()
+		if err != nil {
+			return nil, err
+		}
+		renameRefs(doc, renames)
+
+		renamed := false
+		for _, field := range componentFields {
+			components := componentMap(doc.components(), field)
+			merged := componentMap(m.doc.components(), field)
+			for _, name := range sortedMapKeys(components) {
+				ref := componentRef(field, name)
+				if _, ok := renames[ref]; ok || renamedRefs[ref] {
+					continue
+				}
+
+				existing := merged.MapIndex(reflect.ValueOf(name))
+				if existing.IsValid() && !sameJSON(existing.Interface(), components.MapIndex(reflect.ValueOf(name)).Interface()) {
+					renames[ref] = componentRef(field, source.Name+"_"+name)
+					renamedRefs[renames[ref]] = true
+					renamed = true
+				}
+			}
+		}
+
+		if !renamed {
+			return doc, nil
+		}
+	}
+}
+
+// components adds the components of the document to the merged ones.
+func (m *merger) components(source MergeSource, doc *OpenAPI) {
+	if doc.Components == nil {
+		return
+	}
+	if m.doc.Components == nil {
+		m.doc.Components = &Components{}
+	}
+
+	for _, field := range componentFields {
+		components := componentMap(doc.Components, field)
+		merged := componentMap(m.doc.Components, field)
+		for _, name := range sortedMapKeys(components) {
+			key := reflect.ValueOf(name)
+			component := components.MapIndex(key)
+
+			if existing := merged.MapIndex(key); existing.IsValid() {
+				if !sameJSON(existing.Interface(), component.Interface()) {
+					// The namespaced name of the component is already used.
+					m.conflict(source, appendPointer("/components", field, name), "component already defined differently")
+				}
+				continue
+			}
+
+			if merged.IsNil() {
+				merged.Set(reflect.MakeMap(merged.Type()))
+			}
+			merged.SetMapIndex(key, component)
+		}
+	}
+}
+
+// pathItem adds a path item of the document to the merged ones, gathering the operations of the path items
+// defined by several sources when they only differ by their operations.
+func (m *merger) pathItem(source MergeSource, ptr string, pathItems map[string]PathItem, key string, pathItem PathItem, doc *OpenAPI) {
+	existing, ok := pathItems[key]
+	switch {
+	case !ok:
+		pathItems[key] = pathItem
+		// The operations of the referenced path items are checked as well.
+		if resolved, err := doc.ResolvePathItem(pathItem); err == nil {
+			pathItem = resolved
+		}
+		for _, mo := range pathItem.Operations() {
+			m.operationID(source, appendPointer(ptr, strings.ToLower(mo.Method)), mo.Operation)
+		}
+		return
+	case sameJSON(existing, pathItem):
+		return
+	case existing.Ref != "" || pathItem.Ref != "" || !sameJSON(withoutOperations(existing), withoutOperations(pathItem)):
+		m.conflict(source, ptr, "path item %q already defined differently", key)
+		return
+	}
+
+	for _, mo := range pathItem.Operations() {
+		opPtr := appendPointer(ptr, strings.ToLower(mo.Method))
+		if existing.GetOperation(mo.Method) != nil {
+			m.conflict(source, opPtr, "operation %s %s already defined", mo.Method, key)
+			continue
+		}
+		_ = existing.SetOperation(mo.Method, mo.Operation)
+		m.operationID(source, opPtr, mo.Operation)
+	}
+	pathItems[key] = existing
+}
+
+// withoutOperations returns a copy of a path item without its operations.
+func withoutOperations(pi PathItem) PathItem {
+	for _, method := range pathItemMethods {
+		*pi.operationField(method) = nil
+	}
+
+	return pi
+}
+
+// operationID records the operationId of a merged operation, reporting the duplicates.
+func (m *merger) operationID(source MergeSource, ptr string, op *Operation) {
+	if op.OperationID == "" {
+		return
+	}
+
+	if previous, ok := m.operationIDs[op.OperationID]; ok {
+		m.conflict(source, appendPointer(ptr, "operationId"), "operationId %q already used by %s", op.OperationID, previous)
+		return
+	}
+	m.operationIDs[op.OperationID] = source.Name + " " + ptr
+}
+
+// tags merges tags by name.
+func (m *merger) tags(tags []Tag) {
+tags:
+	for _, tag := range tags {
+		for i := range m.doc.Tags {
+			if merged := &m.doc.Tags[i]; merged.Name == tag.Name {
+				if merged.Description == "" {
+					merged.Description = tag.Description
+				}
+				if merged.ExternalDocs == nil {
+					merged.ExternalDocs = tag.ExternalDocs
+				}
+				continue tags
+			}
+		}
+
+		m.doc.Tags = append(m.doc.Tags, tag)
+	}
+}
+
+// renameRefs replaces the local references to components of the document, and the names of the security schemes
+// of its security requirements, following the renames from reference to reference.
+func renameRefs(doc *OpenAPI, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+
+	rewriteRefs(doc, func(_, ref string) string {
+		if renamed, ok := renames[ref]; ok {
+			return renamed
+		}
+		return ref
+	})
+
+	w := &Walker{}
+	OnEnter(w, func(_ *WalkNode, s *SecurityRequirement) error {
+		renamed := SecurityRequirement{}
+		for name, scopes := range *s {
+			if ref, ok := renames[componentRef("securitySchemes", name)]; ok {
+				name = UnescapePointerToken(ref[strings.LastIndex(ref, "/")+1:])
+			}
+			renamed[name] = scopes
+		}
+		*s = renamed
+		return nil
+	})
+	// The hook does not fail.
+	_ = w.Walk(doc)
+
+	components := doc.components()
+	for _, field := range componentFields {
+		c := componentMap(components, field)
+		for _, name := range sortedMapKeys(c) {
+			renamed, ok := renames[componentRef(field, name)]
+			if !ok {
+				continue
+			}
+
+			key := reflect.ValueOf(name)
+			value := c.MapIndex(key)
+			c.SetMapIndex(key, reflect.Value{})
+			c.SetMapIndex(reflect.ValueOf(UnescapePointerToken(renamed[strings.LastIndex(renamed, "/")+1:])), value)
+		}
+	}
+}
+
+// rewriteRefs replaces the references of the document, the ones of the Reference Objects and of the schemas,
+// and the discriminator mapping values, with the result of fn,
+// given the pointer of the node holding them.
+func rewriteRefs(doc *OpenAPI, fn func(ptr, ref string) string) {
+	w := &Walker{
+		Enter: func(node *WalkNode) error {
+			if ref := refOf(node.Value); ref != "" {
+				reflect.ValueOf(node.Value).Elem().FieldByName("Ref").SetString(fn(node.Pointer, ref))
+			}
+
+			if v, ok := node.Value.(*Schema); ok && v.Discriminator != nil {
+				for key, ref := range v.Discriminator.Mapping {
+					v.Discriminator.Mapping[key] = fn(node.Pointer, ref)
+				}
+			}
+
+			return nil
+		},
+	}
+	// The hook does not fail.
+	_ = w.Walk(doc)
+}
+
+// componentMap returns the map of a field of the components.
+func componentMap(c *Components, field string) reflect.Value {
+	m, _ := fieldByJSONName(reflect.ValueOf(c).Elem(), field)
+	return m
+}
+
+// sortedMapKeys returns the sorted keys of a map with string keys.
+func sortedMapKeys(m reflect.Value) []string {
+	keys := make([]string, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sameJSON reports whether two values have the same JSON representation.
+func sameJSON(a, b any) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
@@ -0,0 +1,158 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	pets, err := FromFile("testdata/merge_pets.yaml")
+	require.NoError(t, err)
+	stores, err := FromFile("testdata/merge_stores.yaml")
+	require.NoError(t, err)
+
+	doc, conflicts, err := Merge(MergeOptions{
+		Info:    Info{Title: "Gateway", Version: "1.0.0"},
+		Servers: []Server{{URL: "https://api.example.com"}},
+	},
+		MergeSource{Name: "pets", Doc: pets, PathPrefix: "/v1/"},
+		MergeSource{Name: "stores", Doc: stores, PathPrefix: "/v1", Servers: []Server{{URL: "https://api.example.com"}}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate())
+
+	var messages []string
+	for _, conflict := range conflicts {
+		messages = append(messages, conflict.String())
+	}
+	assert.Equal(t, []string{
+		`stores /paths/~1pets~1{petId}/get: operation GET /v1/pets/{petId} already defined`,
+		`stores /paths/~1stores/get/operationId: operationId "listPets" already used by pets /paths/~1pets/get`,
+		`stores /webhooks/event/post: operation POST event already defined`,
+	}, messages)
+
+	assert.Equal(t, "3.1.0", doc.Openapi)
+	assert.Equal(t, []Server{{URL: "https://api.example.com"}}, doc.Servers)
+	assert.Empty(t, doc.Security)
+	assert.Equal(t, []string{"/v1/pets", "/v1/pets/{petId}", "/v1/stores"}, sortedKeys(doc.Paths))
+
+	// The servers and the security requirements of the sources are set on their operations.
+	listPets := doc.Paths["/v1/pets"]
+	assert.Equal(t, []Server{{URL: "https://pets.internal"}}, listPets.Get.Servers)
+	assert.Equal(t, []SecurityRequirement{{"apiKey": []string{}}}, listPets.Get.Security)
+	assert.Equal(t, "getPet", doc.Paths["/v1/pets/{petId}"].Get.OperationID)
+	assert.Nil(t, doc.Paths["/v1/stores"].Get.Servers)
+
+	// The operations of the same path are gathered.
+	require.NotNil(t, listPets.Post)
+	assert.Equal(t, "createPet", listPets.Post.OperationID)
+
+	// The identical components are deduplicated, the others namespaced.
+	assert.Equal(t, []string{
+		"#/components/schemas/Error",
+		"#/components/schemas/Pet",
+		"#/components/schemas/Store",
+		"#/components/schemas/stores_Pet",
+		"#/components/responses/Error",
+		"#/components/securitySchemes/apiKey",
+	}, doc.Components.refs())
+	assert.Equal(t, "#/components/schemas/stores_Pet", listPets.Post.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/stores_Pet", doc.Components.Schemas["Store"].Properties["pets"].Items.Ref)
+	assert.Empty(t, doc.UnusedComponents())
+
+	assert.Equal(t, []Tag{{Name: "pets", Description: "Everything about pets."}, {Name: "stores"}}, doc.Tags)
+
+	// The sources are left untouched.
+	assert.Equal(t, "#/components/schemas/Pet", stores.Components.Schemas["Store"].Properties["pets"].Items.Ref)
+	assert.NotNil(t, pets.Security)
+}
+
+func TestMerge_renamedSecurityScheme(t *testing.T) {
+	source := func(scheme string) *OpenAPI {
+		return &OpenAPI{
+			Openapi:  "3.1.0",
+			Info:     Info{Title: "API", Version: "1.0.0"},
+			Security: []SecurityRequirement{{"auth": []string{}}},
+			Paths: Paths{"/" + scheme: PathItem{Get: &Operation{
+				Responses: &Responses{"200": Response{Description: "OK."}},
+			}}},
+			Components: &Components{SecuritySchemes: map[string]SecurityScheme{
+				"auth": {Type: "http", Scheme: scheme},
+			}},
+		}
+	}
+
+	doc, conflicts, err := Merge(MergeOptions{Info: Info{Title: "Gateway", Version: "1.0.0"}},
+		MergeSource{Name: "a", Doc: source("basic")},
+		MergeSource{Name: "b", Doc: source("bearer")},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	assert.Equal(t, []SecurityRequirement{{"auth": []string{}}}, doc.Paths["/basic"].Get.Security)
+	assert.Equal(t, []SecurityRequirement{{"b_auth": []string{}}}, doc.Paths["/bearer"].Get.Security)
+	assert.Equal(t, "bearer", doc.Components.SecuritySchemes["b_auth"].Scheme)
+}
+
+func TestMerge_version(t *testing.T) {
+	legacy := &OpenAPI{Openapi: "3.0.3", Info: Info{Title: "API", Version: "1.0.0"}}
+
+	doc, _, err := Merge(MergeOptions{}, MergeSource{Name: "a", Doc: legacy}, MergeSource{Name: "b", Doc: legacy})
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.3", doc.Openapi)
+
+	doc, _, err = Merge(MergeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.Openapi)
+}
+
+func TestMerge_errors(t *testing.T) {
+	doc := &OpenAPI{Openapi: "3.1.0", Info: Info{Title: "API", Version: "1.0.0"}}
+	legacy := &OpenAPI{Openapi: "3.0.3", Info: Info{Title: "API", Version: "1.0.0"}}
+
+	tests := []struct {
+		desc          string
+		options       MergeOptions
+		sources       []MergeSource
+		expectedError string
+	}{
+		{
+			desc:          "source without name",
+			sources:       []MergeSource{{Doc: doc}},
+			expectedError: "source without name",
+		},
+		{
+			desc:          "duplicated source",
+			sources:       []MergeSource{{Name: "a", Doc: doc}, {Name: "a", Doc: doc}},
+			expectedError: `source "a" already defined`,
+		},
+		{
+			desc:          "source without document",
+			sources:       []MergeSource{{Name: "a"}},
+			expectedError: `source "a": no document`,
+		},
+		{
+			desc:          "mixed versions",
+			sources:       []MergeSource{{Name: "a", Doc: legacy}, {Name: "b", Doc: doc}},
+			expectedError: `source "b": version 3.1.0 cannot be merged into version 3.0.3`,
+		},
+		{
+			desc:          "version of the options",
+			options:       MergeOptions{Openapi: "3.1.0"},
+			sources:       []MergeSource{{Name: "a", Doc: legacy}},
+			expectedError: `source "a": version 3.0.3 cannot be merged into version 3.1.0`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := Merge(test.options, test.sources...)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
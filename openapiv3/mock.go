@@ -0,0 +1,335 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MockHandler is an http.Handler answering the operations of a document with example responses.
+//
+// The response is the lowest success one defined by the operation, unless the request selects another one
+// with the Prefer header, e.g. "Prefer: code=404, example=notFound".
+// The media type is negotiated with the Accept header, and the body is, in order of preference:
+// the selected example of the media type, its example, its first example, the example of its schema,
+// or a value synthesized from its schema.
+type MockHandler struct {
+	// Generator, if set, generates random bodies from the schemas instead of the deterministic samples.
+	Generator *Generator
+
+	doc   *OpenAPI
+	paths pathMatcher
+}
+
+// NewMockHandler returns a MockHandler for a document.
+func NewMockHandler(doc *OpenAPI) *MockHandler {
+	return &MockHandler{doc: doc}
+}
+
+// ServeHTTP answers a request with the example response of the matching operation.
+// The path is matched relatively to the root or to the path of one of the document servers.
+func (m *MockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op, ok := m.matchOperation(r)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no operation %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+		return
+	}
+
+	prefer := parsePrefer(r.Header.Values("Prefer"))
+
+	code, response, err := m.selectResponse(op, prefer["code"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	for _, name := range sortedKeys(response.Headers) {
+		if value, ok := m.headerValue(response.Headers[name]); ok {
+			w.Header().Set(name, value)
+		}
+	}
+
+	if len(response.Content) == 0 {
+		w.WriteHeader(code)
+		return
+	}
+
+	contentType, mediaType, ok := negotiateContent(response.Content, r.Header.Get("Accept"))
+	if !ok {
+		http.Error(w, fmt.Sprintf("no media type acceptable in %s", strings.Join(sortedKeys(response.Content), ", ")), http.StatusNotAcceptable)
+		return
+	}
+
+	if name := prefer["example"]; name != "" {
+		if _, ok := mediaType.Examples[name]; !ok {
+			http.Error(w, fmt.Sprintf("no example %q", name), http.StatusNotFound)
+			return
+		}
+	}
+
+	value, err := m.exampleValue(mediaType, prefer["example"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := encodeMockBody(contentType, value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// matchOperation returns the operation matching the request path, as is or relative to a server path.
+func (m *MockHandler) matchOperation(r *http.Request) (*Operation, bool) {
+	if po, _, ok := m.doc.matchOperation(r.Method, r.URL.Path, &m.paths); ok {
+		return po.Operation, true
+	}
+
+	for _, server := range m.doc.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil || u.Path == "" || u.Path == "/" {
+			continue
+		}
+
+		if path, ok := strings.CutPrefix(r.URL.Path, strings.TrimSuffix(u.Path, "/")); ok {
+			if po, _, ok := m.doc.matchOperation(r.Method, path, &m.paths); ok {
+				return po.Operation, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// selectResponse returns the status code and the response of the preferred code,
+// or of the lowest success code if there is no preference.
+func (m *MockHandler) selectResponse(op *Operation, preferred string) (int, Response, error) {
+	if op.Responses == nil || len(*op.Responses) == 0 {
+		return 0, Response{}, fmt.Errorf("no response defined")
+	}
+	responses := *op.Responses
+
+	var (
+		code     int
+		response Response
+		ok       bool
+	)
+	if preferred != "" {
+		var err error
+		if code, err = strconv.Atoi(preferred); err != nil {
+			return 0, Response{}, fmt.Errorf("invalid preferred code %q", preferred)
+		}
+		if response, ok = responseForStatus(responses, code); !ok {
+			return 0, Response{}, fmt.Errorf("no response defined for code %d", code)
+		}
+	} else {
+		code, response = defaultResponse(responses)
+	}
+
+	response, err := m.doc.ResolveResponse(response)
+	if err != nil {
+		return 0, Response{}, err
+	}
+
+	return code, response, nil
+}
+
+// defaultResponse returns the response of the lowest success code, then of the 2XX range,
+// then the default response, then the response of the lowest code.
+func defaultResponse(responses Responses) (int, Response) {
+	keys := sortedKeys(responses)
+	for _, key := range keys {
+		if code, err := strconv.Atoi(key); err == nil && code >= 200 && code < 300 {
+			return code, responses[key]
+		}
+	}
+
+	for _, key := range []string{"2XX", "default"} {
+		if response, ok := responses[key]; ok {
+			return http.StatusOK, response
+		}
+	}
+
+	code, err := strconv.Atoi(strings.ReplaceAll(keys[0], "X", "0"))
+	if err != nil {
+		code = http.StatusOK
+	}
+
+	return code, responses[keys[0]]
+}
+
+// headerValue returns the example value of a response header, if any.
+func (m *MockHandler) headerValue(header Header) (string, bool) {
+	header, err := m.doc.ResolveHeader(header)
+	if err != nil {
+		return "", false
+	}
+
+	value := header.Example
+	if value == nil && header.Schema != nil && header.Required != nil && *header.Required {
+		value = m.doc.SampleValue(*header.Schema)
+	}
+	if value == nil {
+		return "", false
+	}
+
+	s, err := formatValue(value)
+
+	return s, err == nil
+}
+
+// exampleValue returns the example of a media type, the named one if a name is given.
+func (m *MockHandler) exampleValue(mediaType MediaType, name string) (any, error) {
+	if name != "" {
+		return m.resolveExampleValue(mediaType.Examples[name])
+	}
+
+	if mediaType.Example != nil {
+		return mediaType.Example, nil
+	}
+
+	for _, key := range sortedKeys(mediaType.Examples) {
+		value, err := m.resolveExampleValue(mediaType.Examples[key])
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			return value, nil
+		}
+	}
+
+	if mediaType.Schema == nil {
+		return nil, nil
+	}
+
+	if m.Generator != nil {
+		return m.Generator.Generate(*mediaType.Schema)
+	}
+
+	return m.doc.SampleValue(*mediaType.Schema), nil
+}
+
+func (m *MockHandler) resolveExampleValue(example Example) (any, error) {
+	example, err := m.doc.ResolveExample(example)
+	if err != nil {
+		return nil, err
+	}
+
+	return example.Value, nil
+}
+
+// encodeMockBody encodes a value for a content type: as JSON for the JSON media types,
+// as is for the strings of the other ones.
+func encodeMockBody(contentType string, value any) ([]byte, error) {
+	if s, ok := value.(string); ok && !isJSONMediaType(contentType) {
+		return []byte(s), nil
+	}
+
+	return json.Marshal(value)
+}
+
+// parsePrefer returns the preferences of Prefer headers, e.g. "code=404, example=notFound".
+func parsePrefer(values []string) map[string]string {
+	prefer := map[string]string{}
+	for _, value := range values {
+		for _, preference := range strings.Split(value, ",") {
+			name, v, _ := strings.Cut(strings.TrimSpace(preference), "=")
+			prefer[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+
+	return prefer
+}
+
+// acceptRange is a media range of an Accept header with its quality.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// negotiateContent returns the content type and the media type of a content best matching an Accept header.
+// Without Accept header, application/json is preferred, then the first media type.
+// The media type ranges of the content, as application/*, are skipped since they cannot describe a response.
+func negotiateContent(content map[string]MediaType, accept string) (string, MediaType, bool) {
+	var concrete []string
+	for _, key := range sortedKeys(content) {
+		if !strings.Contains(key, "*") {
+			concrete = append(concrete, key)
+		}
+	}
+	if len(concrete) == 0 {
+		return "", MediaType{}, false
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		for _, key := range concrete {
+			if isJSONMediaType(key) {
+				return key, content[key], true
+			}
+		}
+		return concrete[0], content[concrete[0]], true
+	}
+
+	for _, r := range parseAccept(accept) {
+		for _, key := range concrete {
+			if mediaRangeMatches(r.mediaType, key) {
+				return key, content[key], true
+			}
+		}
+	}
+
+	return "", MediaType{}, false
+}
+
+// parseAccept returns the media ranges of an Accept header sorted by decreasing quality,
+// the ones with a quality of 0 being ignored.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	return ranges
+}
+
+// mediaRangeMatches reports whether a media type matches a media range like */*, text/* or text/plain.
+func mediaRangeMatches(mediaRange, mediaType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+
+	if mediaRange == "*/*" || strings.EqualFold(mediaRange, mediaType) {
+		return true
+	}
+
+	typ, _, _ := strings.Cut(mediaType, "/")
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+
+	return rangeSubtype == "*" && strings.EqualFold(rangeType, typ)
+}
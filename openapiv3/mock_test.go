@@ -0,0 +1,243 @@
+package openapiv3
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockHandler(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	showPet := oas.Paths["/pets/{petId}"].Get
+	(*showPet.Responses)["200"].Content["application/json"] = MediaType{
+		Schema: &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Pet"}},
+		Examples: map[string]Example{
+			"cat":        {Value: map[string]any{"id": 1, "name": "Tom"}},
+			"dog":        {Reference: Reference{Ref: "#/components/examples/dog"}},
+			"unresolved": {Reference: Reference{Ref: "#/components/examples/unknown"}},
+		},
+	}
+	(*showPet.Responses)["200"].Content["text/plain"] = MediaType{Example: "Tom the cat"}
+	(*showPet.Responses)["404"] = Response{
+		Description: "not found",
+		Headers:     map[string]Header{"X-Request-ID": {Parameter: Parameter{Example: "req-1"}}},
+		Content: map[string]MediaType{
+			"application/problem+json": {Example: map[string]any{"title": "Not Found"}},
+		},
+	}
+	oas.Components.Examples = map[string]Example{"dog": {Value: map[string]any{"id": 2, "name": "Rex"}}}
+
+	server := httptest.NewServer(NewMockHandler(oas))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		desc         string
+		method       string
+		path         string
+		header       http.Header
+		expectedCode int
+		expectedType string
+		expectedBody string
+		expectedID   string
+	}{
+		{
+			desc:         "synthesized from the schema",
+			method:       http.MethodGet,
+			path:         "/pets",
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `[{"id":0,"name":"string","tag":"string"}]`,
+		},
+		{
+			desc:         "server path",
+			method:       http.MethodGet,
+			path:         "/v1/pets",
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `[{"id":0,"name":"string","tag":"string"}]`,
+		},
+		{
+			desc:         "first example",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `{"id":1,"name":"Tom"}`,
+		},
+		{
+			desc:         "preferred referenced example",
+			method:       http.MethodGet,
+			path:         "/pets/2",
+			header:       http.Header{"Prefer": []string{"example=dog"}},
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `{"id":2,"name":"Rex"}`,
+		},
+		{
+			desc:         "accepted media type",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			header:       http.Header{"Accept": []string{"application/json;q=0.5, text/*"}},
+			expectedCode: http.StatusOK,
+			expectedType: "text/plain",
+			expectedBody: "Tom the cat",
+		},
+		{
+			desc:         "preferred code",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			header:       http.Header{"Prefer": []string{"code=404"}},
+			expectedCode: http.StatusNotFound,
+			expectedType: "application/problem+json",
+			expectedBody: `{"title":"Not Found"}`,
+			expectedID:   "req-1",
+		},
+		{
+			desc:         "preferred default code",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			header:       http.Header{"Prefer": []string{"code=500"}},
+			expectedCode: http.StatusInternalServerError,
+			expectedType: "application/json",
+			expectedBody: `{"code":0,"message":"string"}`,
+		},
+		{
+			desc:         "no content",
+			method:       http.MethodPost,
+			path:         "/pets",
+			expectedCode: http.StatusCreated,
+		},
+		{
+			desc:         "not acceptable",
+			method:       http.MethodGet,
+			path:         "/pets",
+			header:       http.Header{"Accept": []string{"application/xml"}},
+			expectedCode: http.StatusNotAcceptable,
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "no media type acceptable in application/json\n",
+		},
+		{
+			desc:         "unknown example",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			header:       http.Header{"Prefer": []string{"example=fish"}},
+			expectedCode: http.StatusNotFound,
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "no example \"fish\"\n",
+		},
+		{
+			desc:         "unresolved example",
+			method:       http.MethodGet,
+			path:         "/pets/1",
+			header:       http.Header{"Prefer": []string{"example=unresolved"}},
+			expectedCode: http.StatusInternalServerError,
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "unresolved reference \"#/components/examples/unknown\"\n",
+		},
+		{
+			desc:         "unknown operation",
+			method:       http.MethodDelete,
+			path:         "/pets",
+			expectedCode: http.StatusNotFound,
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "no operation DELETE /pets\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(test.method, server.URL+test.path, http.NoBody)
+			require.NoError(t, err)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+			assert.Equal(t, test.expectedType, resp.Header.Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, string(body))
+			assert.Equal(t, test.expectedID, resp.Header.Get("X-Request-ID"))
+		})
+	}
+}
+
+func TestOpenAPI_SampleValue(t *testing.T) {
+	oas := &OpenAPI{
+		Components: &Components{
+			Schemas: map[string]Schema{
+				"Node": {JSONSchema: JSONSchema{
+					Type:       "object",
+					Properties: map[string]JSONSchema{"next": {Ref: "#/components/schemas/Node"}},
+				}},
+				"Status": {JSONSchema: JSONSchema{Type: "string"}, Example: "active"},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		schema   Schema
+		expected any
+	}{
+		{desc: "example", schema: Schema{JSONSchema: JSONSchema{Type: "string"}, Example: "value"}, expected: "value"},
+		{desc: "enum", schema: Schema{JSONSchema: JSONSchema{Type: "string", Enum: []any{"b", "a"}}}, expected: "b"},
+		{desc: "format", schema: Schema{JSONSchema: JSONSchema{Type: "string", Format: "email"}}, expected: "user@example.com"},
+		{desc: "min length", schema: Schema{JSONSchema: JSONSchema{Type: "string", MinLength: 8}}, expected: "stringss"},
+		{desc: "max length", schema: Schema{JSONSchema: JSONSchema{Type: "string", MaxLength: 3}}, expected: "str"},
+		{desc: "integer bounds", schema: Schema{JSONSchema: JSONSchema{Type: "integer", ExclusiveMinimum: 10.0}}, expected: int64(11)},
+		{desc: "number bounds", schema: Schema{JSONSchema: JSONSchema{Type: "number", Maximum: float64Ptr(-2)}}, expected: -2.0},
+		{desc: "nullable type", schema: Schema{JSONSchema: JSONSchema{Type: []any{"null", "boolean"}}}, expected: nil},
+		{desc: "component example", schema: Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Status"}}, expected: "active"},
+		{
+			desc: "allOf",
+			schema: Schema{JSONSchema: JSONSchema{AllOf: []JSONSchema{
+				{Type: "object", Properties: map[string]JSONSchema{"id": {Type: "integer"}}},
+				{Properties: map[string]JSONSchema{"name": {Type: "string"}}},
+			}}},
+			expected: map[string]any{"id": int64(0), "name": "string"},
+		},
+		{
+			desc:     "oneOf",
+			schema:   Schema{JSONSchema: JSONSchema{OneOf: []JSONSchema{{Type: "boolean"}, {Type: "string"}}}},
+			expected: true,
+		},
+		{
+			desc:     "array",
+			schema:   Schema{JSONSchema: JSONSchema{Type: "array", MinItems: 2, Items: &JSONSchema{Type: "string", Format: "uuid"}}},
+			expected: []any{"3fa85f64-5717-4562-b3fc-2c963f66afa6", "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, oas.SampleValue(test.schema))
+		})
+	}
+
+	// The samples of the recursive schemas are bounded.
+	value := oas.SampleValue(Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Node"}})
+	depth := 0
+	for node, ok := value.(map[string]any); ok; node, ok = node["next"].(map[string]any) {
+		depth++
+	}
+	assert.Positive(t, depth)
+	assert.LessOrEqual(t, depth, maxSampleDepth)
+}
@@ -0,0 +1,320 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// NormalizeDefaults tells how OpenAPI.Normalize handles the default values.
+type NormalizeDefaults int
+
+const (
+	// DefaultsKeep keeps the values as they are.
+	DefaultsKeep NormalizeDefaults = iota
+	// DefaultsExplicit sets the missing values to their default.
+	DefaultsExplicit
+	// DefaultsRemove removes the values equal to their default.
+	DefaultsRemove
+)
+
+// NormalizeOptions configures OpenAPI.Normalize.
+type NormalizeOptions struct {
+	// Defaults tells how the default values of the required, style and explode fields
+	// of the parameters and the headers are handled.
+	Defaults NormalizeDefaults
+	// InlineTrivialRefs replaces the references to the trivial component schemas with their content:
+	// the schemas having only a type other than object and array, a format, a description,
+	// validation keywords of the primitive types, or a single reference.
+	// The component schemas left unreferenced are removed.
+	InlineTrivialRefs bool
+	// ExtractDuplicateSchemas replaces the inline object and composed schemas, appearing several times
+	// or identical to a component schema, with a reference to a component schema, see OpenAPI.DeduplicateSchemas.
+	ExtractDuplicateSchemas bool
+}
+
+// trivialSchemaKeywords are the keywords allowed in a trivial schema.
+var trivialSchemaKeywords = map[string]bool{
+	"$ref": true, "type": true, "format": true, "description": true, "enum": true, "pattern": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+	"minLength": true, "maxLength": true,
+}
+
+// Normalize returns a copy of the document in a canonical form, to compare its versions without noise:
+//   - the media types are lower-cased, e.g. Application/JSON; Charset=UTF-8 becomes application/json; charset=UTF-8;
+//   - the default values, the trivial references and the duplicate inline schemas are handled as configured.
+//
+// The paths, the components and the other maps are sorted by key, and the fields of the objects follow
+// the order of the specification, when the document is encoded, see WriteFile.
+func (o *OpenAPI) Normalize(options NormalizeOptions) (*OpenAPI, error) {
+	doc, err := o.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = normalizeMediaTypes(doc); err != nil {
+		return nil, err
+	}
+
+	if options.Defaults != DefaultsKeep {
+		normalizeDefaults(doc, options.Defaults)
+	}
+
+	if options.InlineTrivialRefs {
+		if err = doc.inlineTrivialSchemas(); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.ExtractDuplicateSchemas {
+		if _, err = doc.DeduplicateSchemas(DeduplicateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// normalizeMediaTypes lower-cases the media types of the contents and of the encodings of a document.
+func normalizeMediaTypes(doc *OpenAPI) error {
+	normalize := func(ptr string, content map[string]MediaType) error {
+		for _, key := range sortedKeys(content) {
+			normalized := normalizeMediaType(key)
+			if normalized == key {
+				continue
+			}
+			if _, ok := content[normalized]; ok {
+				return fmt.Errorf("%s: media types %q and %q are the same", ptr, key, normalized)
+			}
+			content[normalized] = content[key]
+			delete(content, key)
+		}
+		return nil
+	}
+
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, p *Parameter) error {
+		return normalize(node.Pointer+"/content", p.Content)
+	})
+	OnEnter(w, func(node *WalkNode, h *Header) error {
+		return normalize(node.Pointer+"/content", h.Content)
+	})
+	OnEnter(w, func(node *WalkNode, rb *RequestBody) error {
+		return normalize(node.Pointer+"/content", rb.Content)
+	})
+	OnEnter(w, func(node *WalkNode, r *Response) error {
+		return normalize(node.Pointer+"/content", r.Content)
+	})
+	OnEnter(w, func(_ *WalkNode, e *Encoding) error {
+		if e.ContentType == "" {
+			return nil
+		}
+		contentTypes := strings.Split(e.ContentType, ",")
+		for i, contentType := range contentTypes {
+			contentTypes[i] = normalizeMediaType(contentType)
+		}
+		e.ContentType = strings.Join(contentTypes, ", ")
+		return nil
+	})
+
+	return w.Walk(doc)
+}
+
+// normalizeMediaType lower-cases a media type and the names of its parameters,
+// the values of the parameters being case-sensitive.
+func normalizeMediaType(mediaType string) string {
+	mediaType = strings.TrimSpace(mediaType)
+
+	mediaRange, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return strings.ToLower(mediaType)
+	}
+	if formatted := mime.FormatMediaType(mediaRange, params); formatted != "" {
+		return formatted
+	}
+
+	return mediaRange
+}
+
+// normalizeDefaults sets or removes the default values of the parameters and the headers of a document.
+func normalizeDefaults(doc *OpenAPI, defaults NormalizeDefaults) {
+	w := &Walker{}
+	OnEnter(w, func(_ *WalkNode, p *Parameter) error {
+		normalizeParameterDefaults(p, defaults)
+		return nil
+	})
+	OnEnter(w, func(_ *WalkNode, h *Header) error {
+		normalizeParameterDefaults(&h.Parameter, defaults)
+		return nil
+	})
+	// The hooks do not fail.
+	_ = w.Walk(doc)
+}
+
+// normalizeParameterDefaults sets or removes the default values of a parameter, or of a header.
+// The path parameters are always required, and the style and explode only apply to the parameters having a schema.
+func normalizeParameterDefaults(p *Parameter, defaults NormalizeDefaults) {
+	if p.Ref != "" {
+		return
+	}
+
+	style, explode := p.SerializationStyle()
+	defaultStyle, _ := Parameter{In: p.In}.SerializationStyle()
+	hasStyle := p.Schema != nil && len(p.Content) == 0
+
+	switch defaults {
+	case DefaultsExplicit:
+		if p.Required == nil && p.In != "path" {
+			p.Required = boolRef(false)
+		}
+		if hasStyle {
+			p.Style = style
+			p.Explode = boolRef(explode)
+		}
+	case DefaultsRemove:
+		if p.Required != nil && !*p.Required && p.In != "path" {
+			p.Required = nil
+		}
+		if p.Style == defaultStyle {
+			p.Style = ""
+		}
+		if p.Explode != nil && *p.Explode == (style == "form") {
+			p.Explode = nil
+		}
+	}
+}
+
+// boolRef returns a pointer to a bool.
+func boolRef(b bool) *bool {
+	return &b
+}
+
+// inlineTrivialSchemas replaces the references to the trivial component schemas with their content,
+// and removes the inlined ones left unreferenced.
+func (o *OpenAPI) inlineTrivialSchemas() error {
+	trivial, err := o.trivialSchemas()
+	if err != nil || len(trivial) == 0 {
+		return err
+	}
+
+	inlined := map[string]bool{}
+	inline := func(ref string, node any) (any, bool, error) {
+		target, ok := trivial[ref]
+		if !ok {
+			return nil, false, nil
+		}
+		inlined[ref] = true
+		siblings, err := toTree(node)
+		if err != nil {
+			return nil, false, err
+		}
+		tree := copyTree(target).(map[string]any)
+		for key, value := range siblings.(map[string]any) {
+			if key != "$ref" {
+				tree[key] = value
+			}
+		}
+		return tree, true, nil
+	}
+
+	w := &Walker{}
+	OnEnter(w, func(_ *WalkNode, s *Schema) error {
+		tree, ok, err := inline(s.Ref, s)
+		if !ok || err != nil {
+			return err
+		}
+		*s = Schema{}
+		return fromTree(tree, s)
+	})
+	OnEnter(w, func(_ *WalkNode, s *JSONSchema) error {
+		tree, ok, err := inline(s.Ref, s)
+		if !ok || err != nil {
+			return err
+		}
+		*s = JSONSchema{}
+		return fromTree(tree, s)
+	})
+	if err = w.Walk(o); err != nil {
+		return err
+	}
+
+	referenced := map[string]bool{}
+	rewriteRefs(o, func(_, ref string) string {
+		referenced[ref] = true
+		return ref
+	})
+	for ref := range inlined {
+		if !referenced[ref] {
+			o.Components.remove(ref)
+		}
+	}
+
+	return nil
+}
+
+// trivialSchemas returns the content of the trivial component schemas by reference,
+// the references to other trivial schemas being followed.
+func (o *OpenAPI) trivialSchemas() (map[string]map[string]any, error) {
+	trees := map[string]map[string]any{}
+	for name, schema := range o.components().Schemas {
+		tree, err := toTree(schema)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := tree.(map[string]any); ok && isTrivialSchema(m) {
+			trees[componentRef("schemas", name)] = m
+		}
+	}
+
+	trivial := map[string]map[string]any{}
+	for ref, tree := range trees {
+		seen := map[string]bool{ref: true}
+		for {
+			target, ok := tree["$ref"].(string)
+			if !ok || len(tree) > 1 || trees[target] == nil {
+				trivial[ref] = tree
+				break
+			}
+			if seen[target] {
+				// A cycle of references is not trivial.
+				break
+			}
+			seen[target] = true
+			tree = trees[target]
+		}
+	}
+
+	return trivial, nil
+}
+
+// isTrivialSchema reports whether a schema, as decoded from JSON, is trivial, see NormalizeOptions.InlineTrivialRefs.
+func isTrivialSchema(schema map[string]any) bool {
+	for key := range schema {
+		if !trivialSchemaKeywords[key] {
+			return false
+		}
+	}
+
+	s := JSONSchema{Type: schema["type"]}
+	if s.HasType("object") || s.HasType("array") {
+		return false
+	}
+
+	// A reference can only be followed alone, as its siblings would override the target.
+	_, isRef := schema["$ref"]
+	return !isRef || len(schema) == 1
+}
+
+// fromTree decodes a JSON value, decoded as generic Go values, into v.
+func fromTree(tree, v any) error {
+	raw, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err = json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Normalize(t *testing.T) {
+	oas, err := FromFile("testdata/normalize.yaml")
+	require.NoError(t, err)
+	original, err := json.Marshal(oas)
+	require.NoError(t, err)
+
+	normalized, err := oas.Normalize(NormalizeOptions{})
+	require.NoError(t, err)
+
+	pets := normalized.Paths["/pets"]
+	assert.Contains(t, pets.Get.Parameters[2].Content, "application/json")
+	assert.Contains(t, (*pets.Get.Responses)["200"].Content, "application/json; charset=UTF-8")
+	require.Contains(t, pets.Post.RequestBody.Content, "multipart/form-data")
+	assert.Equal(t, "image/png, image/jpeg", pets.Post.RequestBody.Content["multipart/form-data"].Encoding["photo"].ContentType)
+
+	// The defaults and the references are kept.
+	assert.Equal(t, "form", pets.Get.Parameters[0].Style)
+	assert.Equal(t, "#/components/schemas/PetIdAlias", normalized.Paths["/pets/{petId}"].Parameters[0].Schema.Ref)
+
+	// The document is left untouched.
+	actual, err := json.Marshal(oas)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(actual))
+}
+
+func TestOpenAPI_Normalize_defaults(t *testing.T) {
+	oas, err := FromFile("testdata/normalize.yaml")
+	require.NoError(t, err)
+
+	explicit, err := oas.Normalize(NormalizeOptions{Defaults: DefaultsExplicit})
+	require.NoError(t, err)
+
+	parameters := explicit.Paths["/pets"].Get.Parameters
+	assert.Equal(t, Parameter{
+		Name:     "tags",
+		In:       "query",
+		Required: boolPtr(false),
+		Style:    "form",
+		Explode:  boolPtr(false),
+		Schema:   parameters[1].Schema,
+	}, parameters[1])
+	// The style does not apply to the content.
+	assert.Equal(t, "", parameters[2].Style)
+	assert.Nil(t, parameters[2].Explode)
+	// The path parameters are required.
+	assert.Equal(t, boolPtr(true), explicit.Paths["/pets/{petId}"].Parameters[0].Required)
+
+	header := (*explicit.Paths["/pets"].Get.Responses)["200"].Headers["X-Rate-Limit"]
+	assert.Equal(t, "simple", header.Style)
+	assert.Equal(t, boolPtr(false), header.Explode)
+
+	removed, err := explicit.Normalize(NormalizeOptions{Defaults: DefaultsRemove})
+	require.NoError(t, err)
+
+	parameters = removed.Paths["/pets"].Get.Parameters
+	assert.Equal(t, Parameter{Name: "limit", In: "query", Schema: parameters[0].Schema}, parameters[0])
+	assert.Equal(t, Parameter{Name: "tags", In: "query", Explode: boolPtr(false), Schema: parameters[1].Schema}, parameters[1])
+	assert.Equal(t, boolPtr(true), removed.Paths["/pets/{petId}"].Parameters[0].Required)
+
+	header = (*removed.Paths["/pets"].Get.Responses)["200"].Headers["X-Rate-Limit"]
+	assert.Equal(t, "", header.Style)
+	assert.Nil(t, header.Explode)
+}
+
+func TestOpenAPI_Normalize_inlineTrivialRefs(t *testing.T) {
+	oas, err := FromFile("testdata/normalize.yaml")
+	require.NoError(t, err)
+
+	normalized, err := oas.Normalize(NormalizeOptions{InlineTrivialRefs: true})
+	require.NoError(t, err)
+
+	// The alias is followed, and the keywords next to the reference kept.
+	assert.Equal(t, &Schema{JSONSchema: JSONSchema{
+		Type:        "string",
+		Format:      "uuid",
+		Description: "The ID of the pet.",
+	}}, normalized.Paths["/pets/{petId}"].Parameters[0].Schema)
+	assert.Equal(t, JSONSchema{Type: "string", Format: "uuid"}, normalized.Components.Schemas["Pet"].Properties["id"])
+
+	// The items are inlined as any other schema.
+	assert.Equal(t, &JSONSchema{Type: "string", Enum: []any{"cat", "dog"}}, normalized.Paths["/pets"].Get.Parameters[1].Schema.Items)
+
+	// The inlined schemas left unreferenced are removed, the unused and the recursive ones are kept.
+	assert.Equal(t, []string{"Loop", "LoopAlias", "Pet", "Unused"}, sortedKeys(normalized.Components.Schemas))
+}
+
+func TestOpenAPI_Normalize_extractDuplicateSchemas(t *testing.T) {
+	oas, err := FromFile("testdata/normalize.yaml")
+	require.NoError(t, err)
+
+	normalized, err := oas.Normalize(NormalizeOptions{ExtractDuplicateSchemas: true})
+	require.NoError(t, err)
+
+	// The schemas appearing several times are extracted.
+	errorSchema := func(method string) *Schema {
+		pathItem := normalized.Paths["/pets/{petId}"]
+		for _, mo := range pathItem.Operations() {
+			if mo.Method == method {
+				return (*mo.Operation.Responses)["default"].Content["application/json"].Schema
+			}
+		}
+		return nil
+	}
+	assert.Equal(t, &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Schema1"}}, errorSchema("GET"))
+	assert.Equal(t, &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Schema1"}}, errorSchema("DELETE"))
+	assert.Equal(t, []string{"code", "message"}, sortedKeys(normalized.Components.Schemas["Schema1"].Properties))
+
+	// The nested schemas are extracted as well, within the components.
+	name := &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/Schema2"}}
+	assert.Equal(t, name, (*normalized.Paths["/pets"].Post.Responses)["201"].Content["application/json"].Schema)
+	assert.Equal(t, name.JSONSchema, normalized.Paths["/pets"].Post.RequestBody.Content["multipart/form-data"].Schema.Properties["pet"])
+	assert.Equal(t, name.JSONSchema, normalized.Components.Schemas["Pet"].Properties["owner"])
+
+	// The single schemas are kept inline.
+	assert.Equal(t, "object", normalized.Paths["/pets"].Get.Parameters[2].Content["application/json"].Schema.Type)
+}
+
+func TestOpenAPI_Normalize_error(t *testing.T) {
+	oas := &OpenAPI{Paths: Paths{"/pets": PathItem{Get: &Operation{Responses: &Responses{
+		"200": Response{Description: "The pets.", Content: map[string]MediaType{
+			"application/json": {},
+			"Application/JSON": {},
+		}},
+	}}}}}
+
+	_, err := oas.Normalize(NormalizeOptions{})
+	assert.EqualError(t, err, `walk: /paths/~1pets/get/responses/200/content: media types "Application/JSON" and "application/json" are the same`)
+}
+
+func TestOpenAPI_WriteFile(t *testing.T) {
+	oas, err := FromFile("testdata/normalize.yaml")
+	require.NoError(t, err)
+
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		ext := ext
+		t.Run(ext, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), "openapi"+ext)
+			require.NoError(t, oas.WriteFile(file))
+
+			written, err := FromFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, oas, written)
+
+			// The fields follow the order of the specification.
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+			previous := -1
+			for _, key := range []string{"openapi", "info", "paths", "components"} {
+				index := strings.Index(string(content), key)
+				assert.Greater(t, index, previous, key)
+				previous = index
+			}
+		})
+	}
+
+	assert.EqualError(t, oas.WriteFile(filepath.Join(t.TempDir(), "openapi.txt")), `unknown file extension ".txt"`)
+}
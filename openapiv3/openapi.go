@@ -4,12 +4,14 @@
 package openapiv3
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	yamlv2 "gopkg.in/yaml.v2"
 	"sigs.k8s.io/yaml"
 )
 
@@ -65,34 +67,135 @@ type OpenAPI struct {
 	Tags []Tag `json:"tags,omitempty"`
 	// Additional external documentation.
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	// Extensions are the Specification Extensions of the document.
+	Extensions Extensions `json:"-"`
 }
 
 // FromFile loads an OpenAPI from a file.
 func FromFile(path string) (*OpenAPI, error) {
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var oas *OpenAPI
+	if err = json.Unmarshal(content, &oas); err != nil {
+		return nil, fmt.Errorf("marshal spec: %w", err)
+	}
+
+	return oas, nil
+}
+
+// readFile reads a JSON or YAML file, the YAML being converted to JSON.
+func readFile(path string) ([]byte, error) {
 	content, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
 	}
 
 	switch filepath.Ext(path) {
-	case ".json", ".yaml", ".yml":
+	case ".json":
+	case ".yaml", ".yml":
+		content, err = yaml.YAMLToJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("yaml to json: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("unknown file extension %q", filepath.Ext(path))
 	}
 
-	var oas *OpenAPI
-	if filepath.Ext(path) == ".yaml" || filepath.Ext(path) == ".yml" {
-		content, err = yaml.YAMLToJSON(content)
+	return content, nil
+}
+
+// WriteFile writes the document to a JSON or YAML file, according to its extension,
+// the fields of the objects in the order of the specification and the map keys sorted.
+func (o *OpenAPI) WriteFile(path string) error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(o); err != nil {
+		return fmt.Errorf("marshal spec: %w", err)
+	}
+	content := b.Bytes()
+
+	switch filepath.Ext(path) {
+	case ".json":
+	case ".yaml", ".yml":
+		// The YAML is encoded from ordered maps, as converting the JSON would sort the fields.
+		ordered, err := orderedTree(json.NewDecoder(bytes.NewReader(content)))
 		if err != nil {
-			return nil, fmt.Errorf("yaml to json: %w", err)
+			return fmt.Errorf("decode spec: %w", err)
 		}
+		if content, err = yamlv2.Marshal(ordered); err != nil {
+			return fmt.Errorf("json to yaml: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown file extension %q", filepath.Ext(path))
 	}
 
-	if err = json.Unmarshal(content, &oas); err != nil {
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	return nil
+}
+
+// orderedTree decodes the next JSON value of a decoder as generic Go values,
+// the objects being decoded as YAML ordered maps and the numbers as json.Number.
+func orderedTree(dec *json.Decoder) (any, error) {
+	dec.UseNumber()
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		m := yamlv2.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := orderedTree(dec)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, yamlv2.MapItem{Key: key, Value: value})
+		}
+		_, err = dec.Token()
+		return m, err
+	case json.Delim('['):
+		s := []any{}
+		for dec.More() {
+			value, err := orderedTree(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, value)
+		}
+		_, err = dec.Token()
+		return s, err
+	default:
+		return token, nil
+	}
+}
+
+// Clone returns a deep copy of the document, made through its JSON representation.
+func (o *OpenAPI) Clone() (*OpenAPI, error) {
+	content, err := json.Marshal(o)
+	if err != nil {
 		return nil, fmt.Errorf("marshal spec: %w", err)
 	}
 
-	return oas, nil
+	var clone OpenAPI
+	if err = json.Unmarshal(content, &clone); err != nil {
+		return nil, fmt.Errorf("unmarshal spec: %w", err)
+	}
+
+	return &clone, nil
 }
 
 // Validate validates an OpenAPI.
@@ -10,6 +10,10 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
 func TestOpenAPI_FromFile(t *testing.T) {
 	petStoreOAS := &OpenAPI{
 		Openapi: "3.0.0",
@@ -40,7 +44,7 @@ func TestOpenAPI_FromFile(t *testing.T) {
 							Schema: &Schema{
 								JSONSchema: JSONSchema{
 									Type:    "integer",
-									Maximum: 100,
+									Maximum: float64Ptr(100),
 									Format:  "int32",
 								},
 							},
@@ -180,8 +184,8 @@ func TestOpenAPI_FromFile(t *testing.T) {
 					JSONSchema: JSONSchema{
 						Type:     "array",
 						MaxItems: 100,
-						Items: map[string]string{
-							"$ref": "#/components/schemas/Pet",
+						Items: &JSONSchema{
+							Ref: "#/components/schemas/Pet",
 						},
 					},
 				},
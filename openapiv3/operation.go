@@ -78,7 +78,7 @@ type Operation struct {
 	// Tags can be used for logical grouping of operations by resources or any other qualifier.
 	Tags []string `json:"tags,omitempty"`
 	// A short summary of what the operation does.
-	Summary string `json:"summary"`
+	Summary string `json:"summary,omitempty"`
 	// A verbose explanation of the operation behavior.
 	// CommonMark syntax (https://spec.commonmark.org/) MAY be used for rich text representation.
 	Description string `json:"description,omitempty"`
@@ -111,7 +111,7 @@ type Operation struct {
 	// Declares this operation to be deprecated.
 	// Consumers SHOULD refrain from usage of the declared operation.
 	// Default value is false.
-	Deprecated bool `json:"deprecated"`
+	Deprecated bool `json:"deprecated,omitempty"`
 	// A declaration of which security mechanisms can be used for this operation.
 	// The list of values includes alternative security requirement objects that can be used.
 	// Only one of the security requirement objects need to be satisfied to authorize a request.
@@ -122,7 +122,9 @@ type Operation struct {
 	// An alternative server array to service this operation.
 	// If an alternative server object is specified at the Path Item Object or Root level,
 	// it will be overridden by this value.
-	Servers []Server `json:"servers"`
+	Servers []Server `json:"servers,omitempty"`
+	// Extensions are the Specification Extensions of the operation.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates an Operation.
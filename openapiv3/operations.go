@@ -0,0 +1,166 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MethodOperation is an operation of a Path Item with its HTTP method.
+type MethodOperation struct {
+	// Method is the upper-case HTTP method of the operation.
+	Method    string
+	Operation *Operation
+}
+
+// PathOperation is an operation with its location in the document.
+type PathOperation struct {
+	MethodOperation
+
+	// Path is the path template, the webhook name or the callback expression of the Path Item holding the operation.
+	Path string
+	// Pointer is the JSON Pointer of the operation in the document.
+	Pointer string
+}
+
+// Operations returns the defined operations of the Path Item in the order of the specification:
+// GET, PUT, POST, DELETE, OPTIONS, HEAD, PATCH and TRACE.
+func (pi *PathItem) Operations() []MethodOperation {
+	var operations []MethodOperation
+	for _, method := range pathItemMethods {
+		if op := *pi.operationField(method); op != nil {
+			operations = append(operations, MethodOperation{Method: strings.ToUpper(method), Operation: op})
+		}
+	}
+
+	return operations
+}
+
+// GetOperation returns the operation of the given HTTP method, case-insensitive, or nil if there is none.
+func (pi *PathItem) GetOperation(method string) *Operation {
+	field := pi.operationField(strings.ToLower(method))
+	if field == nil {
+		return nil
+	}
+
+	return *field
+}
+
+// SetOperation sets the operation of the given HTTP method, case-insensitive.
+// A nil operation deletes it.
+func (pi *PathItem) SetOperation(method string, op *Operation) error {
+	field := pi.operationField(strings.ToLower(method))
+	if field == nil {
+		return fmt.Errorf("unsupported method %q", method)
+	}
+
+	*field = op
+
+	return nil
+}
+
+// DeleteOperation deletes the operation of the given HTTP method, case-insensitive.
+func (pi *PathItem) DeleteOperation(method string) error {
+	return pi.SetOperation(method, nil)
+}
+
+// Operations returns all the operations of the document in a deterministic order:
+// the operations of the paths sorted by path, each followed by the operations of its callbacks,
+// then the operations of the webhooks sorted by name.
+// The referenced Path Items are not resolved.
+func (o *OpenAPI) Operations() []PathOperation {
+	var operations []PathOperation
+	for _, path := range sortedKeys(o.Paths) {
+		pathItem := o.Paths[path]
+		operations = appendOperations(operations, appendPointer("/paths", path), path, &pathItem)
+	}
+
+	for _, name := range sortedKeys(o.Webhooks) {
+		pathItem := o.Webhooks[name]
+		operations = appendOperations(operations, appendPointer("/webhooks", name), name, &pathItem)
+	}
+
+	return operations
+}
+
+func appendOperations(operations []PathOperation, ptr, path string, pi *PathItem) []PathOperation {
+	for _, mo := range pi.Operations() {
+		opPtr := appendPointer(ptr, strings.ToLower(mo.Method))
+		operations = append(operations, PathOperation{MethodOperation: mo, Path: path, Pointer: opPtr})
+
+		for _, name := range sortedKeys(mo.Operation.Callbacks) {
+			callback := mo.Operation.Callbacks[name]
+			for _, expression := range sortedKeys(callback) {
+				pathItem := callback[expression]
+				operations = appendOperations(operations, appendPointer(opPtr, "callbacks", name, expression), expression, &pathItem)
+			}
+		}
+	}
+
+	return operations
+}
+
+// OperationByID returns the operation with the given operationId.
+func (o *OpenAPI) OperationByID(operationID string) (PathOperation, bool) {
+	for _, po := range o.Operations() {
+		if po.Operation.OperationID == operationID {
+			return po, true
+		}
+	}
+
+	return PathOperation{}, false
+}
+
+// OperationsByTag returns the operations having the given tag.
+func (o *OpenAPI) OperationsByTag(tag string) []PathOperation {
+	var operations []PathOperation
+	for _, po := range o.Operations() {
+		if containsString(po.Operation.Tags, tag) {
+			operations = append(operations, po)
+		}
+	}
+
+	return operations
+}
+
+// MatchOperation returns the operation of the paths matching an HTTP method and a URL path, relative to the server,
+// with the values of the path parameters.
+// As required by the specification, the concrete paths take precedence over the templated ones.
+func (o *OpenAPI) MatchOperation(method, path string) (PathOperation, map[string]string, bool) {
+	return o.matchOperation(method, path, &pathMatcher{})
+}
+
+// matchOperation is MatchOperation with the path templates compiled by a matcher.
+func (o *OpenAPI) matchOperation(method, path string, matcher *pathMatcher) (PathOperation, map[string]string, bool) {
+	var (
+		match      PathOperation
+		params     map[string]string
+		paramCount = -1
+	)
+
+	for _, template := range sortedKeys(o.Paths) {
+		values, ok := matcher.match(template, path)
+		if !ok || paramCount >= 0 && len(values) >= paramCount {
+			continue
+		}
+
+		pathItem, err := o.ResolvePathItem(o.Paths[template])
+		if err != nil {
+			continue
+		}
+
+		op := pathItem.GetOperation(method)
+		if op == nil {
+			continue
+		}
+
+		match = PathOperation{
+			MethodOperation: MethodOperation{Method: strings.ToUpper(method), Operation: op},
+			Path:            template,
+			Pointer:         appendPointer("/paths", template, strings.ToLower(method)),
+		}
+		params = values
+		paramCount = len(values)
+	}
+
+	return match, params, paramCount >= 0
+}
@@ -0,0 +1,109 @@
+package openapiv3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Operations(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	oas.Paths["/pets"].Post.Callbacks = map[string]Callback{
+		"petCreated": {
+			"{$request.body#/callbackUrl}": PathItem{Post: &Operation{OperationID: "petCreatedCallback"}},
+		},
+	}
+	oas.Webhooks = map[string]PathItem{
+		"newPet": {Post: &Operation{OperationID: "newPetWebhook", Tags: []string{"pets"}}},
+	}
+
+	var operations []string
+	for _, po := range oas.Operations() {
+		operations = append(operations, po.Method+" "+po.Path+" "+po.Pointer)
+	}
+
+	assert.Equal(t, []string{
+		"GET /pets /paths/~1pets/get",
+		"POST /pets /paths/~1pets/post",
+		"POST {$request.body#/callbackUrl} /paths/~1pets/post/callbacks/petCreated/{$request.body#~1callbackUrl}/post",
+		"GET /pets/{petId} /paths/~1pets~1{petId}/get",
+		"POST newPet /webhooks/newPet/post",
+	}, operations)
+
+	po, ok := oas.OperationByID("showPetById")
+	require.True(t, ok)
+	assert.Equal(t, "/pets/{petId}", po.Path)
+	assert.Equal(t, "GET", po.Method)
+
+	_, ok = oas.OperationByID("unknown")
+	assert.False(t, ok)
+
+	assert.Len(t, oas.OperationsByTag("pets"), 4)
+}
+
+func TestPathItem_SetOperation(t *testing.T) {
+	var pi PathItem
+
+	op := &Operation{OperationID: "listPets"}
+	require.NoError(t, pi.SetOperation("get", op))
+	assert.Same(t, op, pi.Get)
+	assert.Same(t, op, pi.GetOperation("GET"))
+	assert.Equal(t, []MethodOperation{{Method: "GET", Operation: op}}, pi.Operations())
+
+	require.NoError(t, pi.DeleteOperation("Get"))
+	assert.Nil(t, pi.Get)
+
+	assert.Error(t, pi.SetOperation("connect", op))
+	assert.Nil(t, pi.GetOperation("connect"))
+}
+
+func TestOpenAPI_MatchOperation(t *testing.T) {
+	oas := &OpenAPI{
+		Paths: Paths{
+			"/pets/{petId}": PathItem{Get: &Operation{OperationID: "getPet"}},
+			"/pets/mine":    PathItem{Get: &Operation{OperationID: "getMyPet"}},
+			"/pets":         PathItem{Reference: Reference{Ref: "#/components/pathItems/pets"}},
+		},
+		Components: &Components{
+			PathItems: map[string]PathItem{"pets": {Post: &Operation{OperationID: "createPet"}}},
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		method      string
+		path        string
+		expectedID  string
+		expectedPtr string
+		params      map[string]string
+	}{
+		{desc: "templated", method: "get", path: "/pets/42", expectedID: "getPet", expectedPtr: "/paths/~1pets~1{petId}/get", params: map[string]string{"petId": "42"}},
+		{desc: "concrete first", method: "GET", path: "/pets/mine", expectedID: "getMyPet", expectedPtr: "/paths/~1pets~1mine/get", params: map[string]string{}},
+		{desc: "referenced path item", method: "POST", path: "/pets", expectedID: "createPet", expectedPtr: "/paths/~1pets/post", params: map[string]string{}},
+		{desc: "unknown method", method: "DELETE", path: "/pets/42"},
+		{desc: "unknown path", method: "GET", path: "/toys"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			po, params, ok := oas.MatchOperation(test.method, test.path)
+			if test.expectedID == "" {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, test.expectedID, po.Operation.OperationID)
+			assert.Equal(t, strings.ToUpper(test.method), po.Method)
+			assert.Equal(t, test.expectedPtr, po.Pointer)
+			assert.Equal(t, test.params, params)
+		})
+	}
+}
@@ -39,9 +39,9 @@ type Parameter struct {
 	// - If in is "header" and the name field is "Accept", "Content-Type" or "Authorization", the parameter definition SHALL be ignored.
 	// - For all other cases, the name corresponds to the parameter name used by the in property.
 	// TODO: validation.
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 	// REQUIRED. The location of the parameter. Possible values are "query", "header", "path" or "cookie".
-	In string `json:"in"`
+	In string `json:"in,omitempty"`
 	// A brief description of the parameter.
 	// This could contain examples of use.
 	// CommonMark syntax (https://spec.commonmark.org/) MAY be used for rich text representation.
@@ -62,16 +62,16 @@ type Parameter struct {
 
 	// Describes how the parameter value will be serialized depending on the type of the parameter value.
 	// Default values (based on value of in): for query - form; for path - simple; for header - simple; for cookie - form.
-	// TODO: set default value according to In value.
-	Style string `json:"style"`
+	// See SerializationStyle for the defaulted value.
+	Style string `json:"style,omitempty"`
 	// When this is true, parameter values of type array or object generate separate parameters
 	// for each value of the array or key-value pair of the map.
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true. For all other styles, the default value is false.
-	// TODO: set default value according to Style value.
-	Explode bool `json:"explode"`
+	// See SerializationStyle for the defaulted value.
+	Explode *bool `json:"explode,omitempty"`
 	// The schema defining the type used for the parameter.
-	Schema *Schema `json:"schema"`
+	Schema *Schema `json:"schema,omitempty"`
 	// Example of the parameter’s potential value.
 	// The example SHOULD match the specified schema and encoding properties if present.
 	// The example field is mutually exclusive of the examples field.
@@ -90,6 +90,28 @@ type Parameter struct {
 	// The key is the media type and the value describes it.
 	// The map MUST only contain one entry.
 	Content map[string]MediaType `json:"content,omitempty"`
+	// Extensions are the Specification Extensions of the parameter.
+	Extensions Extensions `json:"-"`
+}
+
+// SerializationStyle returns the style and the explode value of the parameter,
+// defaulted according to its location and style when they are not set.
+func (p Parameter) SerializationStyle() (string, bool) {
+	style := p.Style
+	if style == "" {
+		switch p.In {
+		case "query", "cookie":
+			style = "form"
+		default:
+			style = "simple"
+		}
+	}
+
+	if p.Explode != nil {
+		return style, *p.Explode
+	}
+
+	return style, style == "form"
 }
 
 // Validate validates a Parameter.
@@ -168,3 +168,63 @@ func TestParameter_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestParameter_SerializationStyle(t *testing.T) {
+	tests := []struct {
+		desc            string
+		parameter       Parameter
+		expectedStyle   string
+		expectedExplode bool
+	}{
+		{
+			desc:            "query",
+			parameter:       Parameter{In: "query"},
+			expectedStyle:   "form",
+			expectedExplode: true,
+		},
+		{
+			desc:            "query without explode",
+			parameter:       Parameter{In: "query", Explode: boolPtr(false)},
+			expectedStyle:   "form",
+			expectedExplode: false,
+		},
+		{
+			desc:          "path",
+			parameter:     Parameter{In: "path"},
+			expectedStyle: "simple",
+		},
+		{
+			desc:          "header",
+			parameter:     Parameter{In: "header"},
+			expectedStyle: "simple",
+		},
+		{
+			desc:            "cookie",
+			parameter:       Parameter{In: "cookie"},
+			expectedStyle:   "form",
+			expectedExplode: true,
+		},
+		{
+			desc:          "deep object",
+			parameter:     Parameter{In: "query", Style: "deepObject"},
+			expectedStyle: "deepObject",
+		},
+		{
+			desc:            "exploded label",
+			parameter:       Parameter{In: "path", Style: "label", Explode: boolPtr(true)},
+			expectedStyle:   "label",
+			expectedExplode: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			style, explode := test.parameter.SerializationStyle()
+			assert.Equal(t, test.expectedStyle, style)
+			assert.Equal(t, test.expectedExplode, explode)
+		})
+	}
+}
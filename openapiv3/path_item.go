@@ -94,6 +94,8 @@ type PathItem struct {
 	// A unique parameter is defined by a combination of a name and location.
 	// The list can use the Reference Object to link to parameters that are defined at the OpenAPI Object’s components/parameters.
 	Parameters []Parameter `json:"parameters,omitempty"`
+	// Extensions are the Specification Extensions of the path item.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates a PathItem.
@@ -141,3 +143,27 @@ func (pi *PathItem) Validate() error {
 
 	return nil
 }
+
+// operationField returns the field holding the operation of the given lower-case HTTP method, nil if there is none.
+func (pi *PathItem) operationField(method string) **Operation {
+	switch method {
+	case "get":
+		return &pi.Get
+	case "put":
+		return &pi.Put
+	case "post":
+		return &pi.Post
+	case "delete":
+		return &pi.Delete
+	case "options":
+		return &pi.Options
+	case "head":
+		return &pi.Head
+	case "patch":
+		return &pi.Patch
+	case "trace":
+		return &pi.Trace
+	default:
+		return nil
+	}
+}
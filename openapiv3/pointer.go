@@ -0,0 +1,415 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// ErrPointerNotFound is returned when a JSON Pointer does not point to an existing node.
+var ErrPointerNotFound = errors.New("pointer not found")
+
+// Pointer is a [JSON Pointer] held as its unescaped reference tokens.
+// The empty Pointer points to the whole document.
+//
+// [JSON Pointer]: https://www.rfc-editor.org/rfc/rfc6901
+type Pointer []string
+
+// ParsePointer parses the string representation of a JSON Pointer.
+func ParsePointer(s string) (Pointer, error) {
+	if s == "" {
+		return Pointer{}, nil
+	}
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("pointer %q must start with /", s)
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || token[j+1] != '0' && token[j+1] != '1') {
+				return nil, fmt.Errorf("pointer %q: invalid escape sequence in %q", s, token)
+			}
+		}
+		tokens[i] = UnescapePointerToken(token)
+	}
+
+	return tokens, nil
+}
+
+// String returns the string representation of the pointer, with its tokens escaped.
+func (p Pointer) String() string {
+	return appendPointer("", p...)
+}
+
+// Append returns a new pointer with the given tokens appended.
+func (p Pointer) Append(tokens ...string) Pointer {
+	ptr := make(Pointer, 0, len(p)+len(tokens))
+	ptr = append(ptr, p...)
+
+	return append(ptr, tokens...)
+}
+
+// EscapePointerToken escapes a reference token: ~ becomes ~0 and / becomes ~1.
+func EscapePointerToken(token string) string {
+	return pointerEscaper.Replace(token)
+}
+
+// UnescapePointerToken reverts EscapePointerToken.
+func UnescapePointerToken(token string) string {
+	return pointerUnescaper.Replace(token)
+}
+
+// appendPointer appends the given reference tokens, escaped, to a JSON Pointer.
+func appendPointer(ptr string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(ptr)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(EscapePointerToken(token))
+	}
+
+	return b.String()
+}
+
+// splitPointer returns the unescaped reference tokens of a JSON Pointer.
+func splitPointer(ptr string) []string {
+	if ptr == "" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(ptr, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = UnescapePointerToken(token)
+	}
+
+	return tokens
+}
+
+// Lookup returns the node of the document at the given JSON Pointer.
+// The node is returned as held by the model, e.g. a PathItem for /paths/~1pets and an *Operation for /paths/~1pets/get.
+func (o *OpenAPI) Lookup(ptr string) (any, error) {
+	tokens, err := ParsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(o)
+	for i, token := range tokens {
+		v, err = pointerChild(v, token)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", Pointer(tokens[:i+1]), err)
+		}
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, fmt.Errorf("%s: %w", ptr, ErrPointerNotFound)
+	}
+
+	return v.Interface(), nil
+}
+
+// Set sets the node of the document at the given JSON Pointer.
+// The missing maps and objects along the pointer are created, and the "-" token appends to an array.
+// The x- tokens of the extensible objects set their extensions.
+// The value is assigned as is when its type matches the node type,
+// otherwise it is converted through its JSON representation.
+func (o *OpenAPI) Set(ptr string, value any) error {
+	tokens, err := ParsePointer(ptr)
+	if err != nil {
+		return err
+	}
+
+	if err = setPointer(reflect.ValueOf(o).Elem(), tokens, value); err != nil {
+		return fmt.Errorf("set %s: %w", ptr, err)
+	}
+
+	return nil
+}
+
+// PointerOf returns the JSON Pointer of a node of the document.
+// The node must be a pointer into the document, such as an *Operation of a PathItem,
+// a *Schema of a MediaType or the address of a slice element like &operation.Parameters[0].
+func (o *OpenAPI) PointerOf(node any) (string, bool) {
+	target := reflect.ValueOf(node)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return "", false
+	}
+
+	return findPointer(reflect.ValueOf(o), target, "")
+}
+
+// pointerChild returns the child of a value identified by a reference token.
+func pointerChild(v reflect.Value, token string) (reflect.Value, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, ErrPointerNotFound
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if field, ok := fieldByJSONName(v, token); ok {
+			return field, nil
+		}
+		if extensions, ok := extensionsField(v, token); ok {
+			if child := extensions.MapIndex(reflect.ValueOf(token)); child.IsValid() {
+				return child, nil
+			}
+		}
+	case reflect.Map:
+		if child := v.MapIndex(reflect.ValueOf(token).Convert(v.Type().Key())); child.IsValid() {
+			return child, nil
+		}
+	case reflect.Slice:
+		if i, ok := arrayIndex(token, v.Len()); ok {
+			return v.Index(i), nil
+		}
+	}
+
+	return reflect.Value{}, ErrPointerNotFound
+}
+
+// extensionsField returns the Extensions field of an extensible object holding the extension named by the token.
+func extensionsField(v reflect.Value, token string) (reflect.Value, bool) {
+	if !strings.HasPrefix(token, "x-") {
+		return reflect.Value{}, false
+	}
+
+	extensions := v.FieldByName("Extensions")
+	if !extensions.IsValid() || extensions.Kind() != reflect.Map {
+		return reflect.Value{}, false
+	}
+
+	return extensions, true
+}
+
+// arrayIndex returns the index of an array of the given length identified by a reference token.
+// As required by RFC 6901, the index is made of digits without leading zero.
+func arrayIndex(token string, length int) (int, bool) {
+	if token == "" || token[0] == '0' && len(token) > 1 {
+		return 0, false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i >= length {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// setPointer assigns the value at the tokens relative to v, which must be settable.
+func setPointer(v reflect.Value, tokens []string, value any) error {
+	if len(tokens) == 0 {
+		return assign(v, value)
+	}
+	token, rest := tokens[0], tokens[1:]
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPointer(v.Elem(), tokens, value)
+	case reflect.Interface:
+		if v.IsNil() {
+			return ErrPointerNotFound
+		}
+		// The dynamic value of an interface is not settable: it is copied, updated, then stored back.
+		c := reflect.New(v.Elem().Type()).Elem()
+		c.Set(v.Elem())
+		if err := setPointer(c, tokens, value); err != nil {
+			return err
+		}
+		v.Set(c)
+		return nil
+	case reflect.Struct:
+		if field, ok := fieldByJSONName(v, token); ok {
+			return setPointer(field, rest, value)
+		}
+		if extensions, ok := extensionsField(v, token); ok {
+			return setPointer(extensions, tokens, value)
+		}
+		return ErrPointerNotFound
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(token).Convert(v.Type().Key())
+		// Map values are not settable: they are copied, updated, then stored back.
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := setPointer(elem, rest, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice:
+		if token == "-" && len(rest) == 0 {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assign(elem, value); err != nil {
+				return err
+			}
+			v.Set(reflect.Append(v, elem))
+			return nil
+		}
+		i, ok := arrayIndex(token, v.Len())
+		if !ok {
+			return ErrPointerNotFound
+		}
+		return setPointer(v.Index(i), rest, value)
+	default:
+		return ErrPointerNotFound
+	}
+}
+
+// assign sets the value to v, converting it through its JSON representation if its type does not match.
+func assign(v reflect.Value, value any) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal value: %w", err)
+	}
+
+	converted := reflect.New(v.Type())
+	if err = json.Unmarshal(raw, converted.Interface()); err != nil {
+		return fmt.Errorf("convert value to %s: %w", v.Type(), err)
+	}
+	v.Set(converted.Elem())
+
+	return nil
+}
+
+// fieldByJSONName returns the field of a struct with the given JSON name.
+// As for encoding/json, the fields of the struct take precedence over the ones of the embedded structs.
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && tagName == "" && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, v.Field(i))
+			continue
+		}
+
+		switch tagName {
+		case "-":
+			continue
+		case "":
+			tagName = field.Name
+		}
+
+		if tagName == name {
+			return v.Field(i), true
+		}
+	}
+
+	for _, e := range embedded {
+		if field, ok := fieldByJSONName(e, name); ok {
+			return field, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// findPointer returns the JSON Pointer of the target within v.
+func findPointer(v, target reflect.Value, ptr string) (string, bool) {
+	if v.Kind() == reflect.Pointer && v.Type() == target.Type() && !v.IsNil() && v.Pointer() == target.Pointer() {
+		return ptr, true
+	}
+	if v.CanAddr() && v.Addr().Type() == target.Type() && v.Addr().Pointer() == target.Pointer() {
+		return ptr, true
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return findPointer(v.Elem(), target, ptr)
+		}
+	case reflect.Struct:
+		return findFieldPointer(v, target, ptr)
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make(map[string]reflect.Value, len(keys))
+		for _, key := range keys {
+			names[key.String()] = key
+		}
+		for _, name := range sortedKeys(names) {
+			if found, ok := findPointer(v.MapIndex(names[name]), target, appendPointer(ptr, name)); ok {
+				return found, true
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if found, ok := findPointer(v.Index(i), target, appendPointer(ptr, strconv.Itoa(i))); ok {
+				return found, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func findFieldPointer(v, target reflect.Value, ptr string) (string, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case tagName == "-":
+			continue
+		case field.Anonymous && tagName == "":
+			// The fields of an embedded struct are at the same level as the ones of the struct.
+			if found, ok := findPointer(v.Field(i), target, ptr); ok {
+				return found, true
+			}
+			continue
+		case tagName == "":
+			tagName = field.Name
+		}
+
+		if found, ok := findPointer(v.Field(i), target, appendPointer(ptr, tagName)); ok {
+			return found, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,138 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		desc              string
+		ptr               string
+		expected          Pointer
+		expectedAssertion assert.ErrorAssertionFunc
+	}{
+		{
+			desc:              "whole document",
+			ptr:               "",
+			expected:          Pointer{},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "escaped tokens",
+			ptr:               "/paths/~1pets~1{petId}/get/x~0y",
+			expected:          Pointer{"paths", "/pets/{petId}", "get", "x~y"},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "escaped tilde before one",
+			ptr:               "/~01",
+			expected:          Pointer{"~1"},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "missing leading slash",
+			ptr:               "paths",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "invalid escape sequence",
+			ptr:               "/paths/~2",
+			expectedAssertion: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ptr, err := ParsePointer(test.ptr)
+			test.expectedAssertion(t, err)
+			assert.Equal(t, test.expected, ptr)
+			if err == nil {
+				assert.Equal(t, test.ptr, ptr.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPI_Lookup(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	node, err := oas.Lookup("/paths/~1pets/get")
+	require.NoError(t, err)
+	assert.Same(t, oas.Paths["/pets"].Get, node)
+
+	node, err = oas.Lookup("/paths/~1pets/get/parameters/0/name")
+	require.NoError(t, err)
+	assert.Equal(t, "limit", node)
+
+	node, err = oas.Lookup("/components/schemas/Pet/properties/id/format")
+	require.NoError(t, err)
+	assert.Equal(t, "int64", node)
+
+	_, err = oas.Lookup("/paths/~1pets/put")
+	assert.ErrorIs(t, err, ErrPointerNotFound)
+
+	_, err = oas.Lookup("/paths/~1owners")
+	assert.ErrorIs(t, err, ErrPointerNotFound)
+
+	// The array indexes are digits without leading zero.
+	for _, index := range []string{"+0", "00", "-0", " 0"} {
+		_, err = oas.Lookup("/paths/~1pets/get/parameters/" + index)
+		assert.ErrorIs(t, err, ErrPointerNotFound, index)
+	}
+}
+
+func TestOpenAPI_Set(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, oas.Set("/paths/~1pets/get/summary", "List pets"))
+	assert.Equal(t, "List pets", oas.Paths["/pets"].Get.Summary)
+
+	require.NoError(t, oas.Set("/paths/~1owners/get", map[string]any{"operationId": "listOwners"}))
+	assert.Equal(t, "listOwners", oas.Paths["/owners"].Get.OperationID)
+
+	require.NoError(t, oas.Set("/components/schemas/Pet/required/-", "tag"))
+	assert.Equal(t, []string{"id", "name", "tag"}, oas.Components.Schemas["Pet"].Required)
+
+	require.NoError(t, oas.Set("/tags/-", Tag{Name: "pets"}))
+	assert.Equal(t, []Tag{{Name: "pets"}}, oas.Tags)
+
+	// The extensions are set as they are looked up.
+	require.NoError(t, oas.Set("/paths/~1pets/get/x-rate-limit", map[string]any{"limit": 10}))
+	require.NoError(t, oas.Set("/paths/~1pets/get/x-rate-limit/limit", 20))
+	node, err := oas.Lookup("/paths/~1pets/get/x-rate-limit")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"limit": 20}, node)
+
+	assert.Error(t, oas.Set("/paths/~1pets/get/unknown", "value"))
+	assert.Error(t, oas.Set("/paths/~1pets/get/summary", map[string]any{}))
+	assert.Error(t, oas.Set("/paths/~1pets/get/parameters/+0/name", "offset"))
+	assert.Error(t, oas.Set("/paths/~1pets/get/parameters/00/name", "offset"))
+}
+
+func TestOpenAPI_PointerOf(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	ptr, ok := oas.PointerOf(oas.Paths["/pets/{petId}"].Get)
+	require.True(t, ok)
+	assert.Equal(t, "/paths/~1pets~1{petId}/get", ptr)
+
+	ptr, ok = oas.PointerOf(&oas.Paths["/pets"].Get.Parameters[0])
+	require.True(t, ok)
+	assert.Equal(t, "/paths/~1pets/get/parameters/0", ptr)
+
+	ptr, ok = oas.PointerOf(oas.Paths["/pets"].Get.Parameters[0].Schema)
+	require.True(t, ok)
+	assert.Equal(t, "/paths/~1pets/get/parameters/0/schema", ptr)
+
+	_, ok = oas.PointerOf(&Operation{})
+	assert.False(t, ok)
+}
@@ -32,7 +32,7 @@ import "errors"
 type Reference struct {
 	// Reference a Parameter
 	// REQUIRED. The reference identifier. This MUST be in the form of a URI.
-	Ref string `json:"$ref"`
+	Ref string `json:"$ref,omitempty"`
 	// A short summary which by default SHOULD override that of the referenced component.
 	// If the referenced object-type does not allow a summary field, then this field has no effect.
 	Summary string `json:"summary,omitempty"`
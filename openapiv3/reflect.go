@@ -0,0 +1,666 @@
+package openapiv3
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reflector derives an OpenAPI document from Go types and handlers, code first.
+//
+// The schemas of the Go types are reflected according to their encoding/json representation:
+//   - the booleans, integers, floats and strings are described by their JSON type,
+//     with the int32, int64, float and double formats, a minimum of 0 for the unsigned integers;
+//   - a time.Time is a date-time string, a []byte a byte string,
+//     a type implementing encoding.TextMarshaler a string, and an interface or json.Marshaler any value;
+//   - a slice or an array is an array, whose items are described by the schema of its elements;
+//   - a map is an object whose additional properties are described by the schema of the map values;
+//   - a named struct is a component of the schemas, referenced by the schemas using it,
+//     and an anonymous struct an inline object.
+//
+// The properties of a struct are named after the json tag of its exported fields, and the fields of the
+// embedded structs without json tag are promoted.
+// A property is required unless its json tag has the omitempty option.
+// The openapi struct tag refines the schema of a field with comma separated keys and values,
+// the values of the description, title and pattern keys being quoted in single quotes if they have commas:
+//
+//	Name  string `json:"name" openapi:"description='The name, unique',minLength=1,maxLength=64"`
+//	Kind  string `json:"kind,omitempty" openapi:"enum=cat|dog,format=kind"`
+//	Count int    `json:"count" openapi:"minimum=1,maximum=10"`
+//
+// The supported keys are description, title, format, pattern, enum, with its values separated by |,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength, minItems and maxItems,
+// and the required and optional flags overriding the omitempty option.
+// The keys constraining a value, like format, enum, minimum or maxLength, constrain the items of the arrays.
+type Reflector struct {
+	doc *OpenAPI
+	// names are the names of the components of the named structs.
+	names map[reflect.Type]string
+}
+
+// OperationSpec describes an operation registered with Reflector.AddOperation.
+type OperationSpec struct {
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	// Security overrides the security requirements of the document, if not nil.
+	Security []SecurityRequirement
+
+	// Params is a value of a struct whose fields are the parameters of the operation, tagged with their location
+	// and name, e.g. `path:"petId"`, `query:"limit"`, `header:"X-Request-ID"` or `cookie:"session"`.
+	// The path parameters are required, the others if their openapi tag has the required flag.
+	Params any
+	// Body is a value of the type of the JSON request body, nil if the operation has none.
+	Body any
+	// Responses maps the status codes, ranges of status codes or default to a value of the type of the JSON
+	// response body, nil for a response without body.
+	Responses map[string]any
+}
+
+// parameterLocations are the struct tags of the parameter locations.
+var parameterLocations = []string{"path", "query", "header", "cookie"}
+
+// NewReflector returns a Reflector completing a document with the schemas and operations it reflects.
+// The document holds the other fields, like its info, servers or security schemes.
+// Its version defaults to 3.1.0.
+func NewReflector(doc *OpenAPI) *Reflector {
+	if doc.Openapi == "" {
+		doc.Openapi = "3.1.0"
+	}
+
+	return &Reflector{doc: doc, names: map[reflect.Type]string{}}
+}
+
+// Schema returns the schema of the type of a value, declaring the components of the named structs it uses.
+// The schema of a named struct is a reference to its component.
+func (r *Reflector) Schema(v any) (Schema, error) {
+	schema, err := r.schema(reflect.TypeOf(v))
+	if err != nil {
+		return Schema{}, err
+	}
+
+	return Schema{JSONSchema: schema}, nil
+}
+
+// AddOperation declares the operation of a handler for a path template, like /pets/{petId}.
+func (r *Reflector) AddOperation(method, path string, spec OperationSpec) error {
+	method = strings.ToUpper(method)
+	if err := r.addOperation(method, path, spec); err != nil {
+		return fmt.Errorf("%s %q: %w", method, path, err)
+	}
+
+	return nil
+}
+
+func (r *Reflector) addOperation(method, path string, spec OperationSpec) error {
+	if !strings.HasPrefix(path, "/") {
+		return errors.New("path must start with /")
+	}
+
+	if r.doc.Paths == nil {
+		r.doc.Paths = Paths{}
+	}
+	pathItem := r.doc.Paths[path]
+	field := pathItem.operationField(strings.ToLower(method))
+	if field == nil {
+		return errors.New("unsupported method")
+	}
+	if *field != nil {
+		return errors.New("operation already registered")
+	}
+
+	op := &Operation{
+		OperationID: spec.OperationID,
+		Summary:     spec.Summary,
+		Description: spec.Description,
+		Tags:        spec.Tags,
+		Deprecated:  spec.Deprecated,
+		Security:    spec.Security,
+	}
+
+	var err error
+	if op.Parameters, err = r.parameters(spec.Params); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+
+	_, names := pathPattern(path)
+	for _, name := range names {
+		if !hasParameter(op.Parameters, name, "path") {
+			return fmt.Errorf("missing path parameter %q", name)
+		}
+	}
+	for _, p := range op.Parameters {
+		if p.In == "path" && !containsString(names, p.Name) {
+			return fmt.Errorf("path parameter %q is not in the path", p.Name)
+		}
+	}
+
+	if spec.Body != nil {
+		schema, err := r.Schema(spec.Body)
+		if err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+		op.RequestBody = &RequestBody{
+			Content:  map[string]MediaType{"application/json": {Schema: &schema}},
+			Required: true,
+		}
+	}
+
+	if len(spec.Responses) > 0 {
+		responses := Responses{}
+		for _, code := range sortedKeys(spec.Responses) {
+			response, err := r.response(code, spec.Responses[code])
+			if err != nil {
+				return fmt.Errorf("response %q: %w", code, err)
+			}
+			responses[code] = response
+		}
+		op.Responses = &responses
+	}
+
+	*field = op
+	r.doc.Paths[path] = pathItem
+
+	return nil
+}
+
+// hasParameter reports whether a parameter is declared.
+func hasParameter(params []Parameter, name, in string) bool {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+
+	return false
+}
+
+// response returns the response of a status code with the body of the type of a value.
+func (r *Reflector) response(code string, body any) (Response, error) {
+	description := "Default response"
+	if status, err := strconv.Atoi(code); err == nil {
+		description = http.StatusText(status)
+	} else if code != "default" {
+		description = code + " response"
+	}
+	if description == "" {
+		description = code + " response"
+	}
+
+	response := Response{Description: description}
+	if body != nil {
+		schema, err := r.Schema(body)
+		if err != nil {
+			return Response{}, err
+		}
+		response.Content = map[string]MediaType{"application/json": {Schema: &schema}}
+	}
+
+	return response, nil
+}
+
+// parameters returns the parameters described by the fields of a struct.
+func (r *Reflector) parameters(v any) ([]Parameter, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+
+	var params []Parameter
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+
+		for _, in := range parameterLocations {
+			name, ok := f.Tag.Lookup(in)
+			if !ok {
+				continue
+			}
+
+			tag, err := parseOpenAPITag(f.Tag.Get("openapi"))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+
+			schema, err := r.schema(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			if err = tag.apply(&schema, f.Type); err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+
+			// The description is the one of the parameter rather than of its schema.
+			description := schema.Description
+			schema.Description = ""
+
+			p := Parameter{Name: name, In: in, Description: description, Schema: &Schema{JSONSchema: schema}}
+			if required := in == "path" || tag.required != nil && *tag.required; required {
+				p.Required = &required
+			}
+			params = append(params, p)
+		}
+	}
+
+	return params, nil
+}
+
+// OpenAPI returns the document, once validated.
+func (r *Reflector) OpenAPI() (*OpenAPI, error) {
+	if err := r.doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r.doc, nil
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	bytesType         = reflect.TypeOf([]byte(nil))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schema returns the schema of a type.
+func (r *Reflector) schema(t reflect.Type) (JSONSchema, error) {
+	if t == nil {
+		return JSONSchema{}, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return JSONSchema{Type: "string", Format: "date-time"}, nil
+	case t == bytesType:
+		return JSONSchema{Type: "string", Format: "byte"}, nil
+	case implements(t, jsonMarshalerType):
+		return JSONSchema{}, nil
+	case implements(t, textMarshalerType):
+		return JSONSchema{Type: "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return JSONSchema{Type: "boolean"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return JSONSchema{Type: "integer", Format: "int32"}, nil
+	case reflect.Int, reflect.Int64:
+		return JSONSchema{Type: "integer", Format: "int64"}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return JSONSchema{Type: "integer", Format: "int32", Minimum: new(float64)}, nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return JSONSchema{Type: "integer", Format: "int64", Minimum: new(float64)}, nil
+	case reflect.Float32:
+		return JSONSchema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return JSONSchema{Type: "number", Format: "double"}, nil
+	case reflect.String:
+		return JSONSchema{Type: "string"}, nil
+	case reflect.Interface:
+		return JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		return r.arraySchema(t)
+	case reflect.Map:
+		return r.mapSchema(t)
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return r.componentSchema(t)
+	default:
+		return JSONSchema{}, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// implements reports whether a type or a pointer to it implements an interface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// arraySchema returns the schema of a slice or an array.
+func (r *Reflector) arraySchema(t reflect.Type) (JSONSchema, error) {
+	items, err := r.schema(t.Elem())
+	if err != nil {
+		return JSONSchema{}, err
+	}
+
+	schema := JSONSchema{Type: "array", Items: &items}
+	if t.Kind() == reflect.Array {
+		schema.MinItems, schema.MaxItems = t.Len(), t.Len()
+	}
+
+	return schema, nil
+}
+
+// mapSchema returns the schema of a map.
+func (r *Reflector) mapSchema(t reflect.Type) (JSONSchema, error) {
+	switch t.Key().Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		if !implements(t.Key(), textMarshalerType) {
+			return JSONSchema{}, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+	}
+
+	values, err := r.schema(t.Elem())
+	if err != nil {
+		return JSONSchema{}, err
+	}
+
+	return JSONSchema{Type: "object", AdditionalProperties: values}, nil
+}
+
+// componentSchema returns the reference to the component of a named struct, declaring it the first time.
+func (r *Reflector) componentSchema(t reflect.Type) (JSONSchema, error) {
+	if name, ok := r.names[t]; ok {
+		return JSONSchema{Ref: "#/components/schemas/" + name}, nil
+	}
+
+	if r.doc.Components == nil {
+		r.doc.Components = &Components{}
+	}
+	if r.doc.Components.Schemas == nil {
+		r.doc.Components.Schemas = map[string]Schema{}
+	}
+
+	base := componentName(t.Name())
+	name := base
+	for i := 2; ; i++ {
+		if _, ok := r.doc.Components.Schemas[name]; !ok {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+
+	// The name is reserved before the fields are reflected, for the recursive types to reference it.
+	r.names[t] = name
+	r.doc.Components.Schemas[name] = Schema{}
+
+	schema, err := r.structSchema(t)
+	if err != nil {
+		delete(r.names, t)
+		delete(r.doc.Components.Schemas, name)
+		return JSONSchema{}, err
+	}
+	r.doc.Components.Schemas[name] = Schema{JSONSchema: schema}
+
+	return JSONSchema{Ref: "#/components/schemas/" + name}, nil
+}
+
+// componentNameSeparators split the name of an instantiated generic type, like Page[example.com/pets.Pet].
+var componentNameSeparators = regexp.MustCompile(`[\[\],\s*]+`)
+
+// componentName returns the name of the component of a type name, the package paths of its type arguments removed,
+// e.g. PagePet for Page[example.com/pets.Pet].
+func componentName(typeName string) string {
+	var b strings.Builder
+	for _, part := range componentNameSeparators.Split(typeName, -1) {
+		if i := strings.LastIndex(part, "."); i >= 0 {
+			part = part[i+1:]
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+// structSchema returns the object schema of a struct.
+func (r *Reflector) structSchema(t reflect.Type) (JSONSchema, error) {
+	schema := JSONSchema{Type: "object"}
+	if err := r.addFields(&schema, t, map[reflect.Type]bool{}); err != nil {
+		return JSONSchema{}, err
+	}
+
+	return schema, nil
+}
+
+// addFields adds the properties of the fields of a struct to a schema, promoting the fields of the embedded structs.
+// The properties already defined, by the fields of an embedding struct, are kept.
+func (r *Reflector) addFields(schema *JSONSchema, t reflect.Type, visited map[reflect.Type]bool) error {
+	if visited[t] {
+		return nil
+	}
+	visited[t] = true
+
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		jsonTag := f.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(jsonTag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+
+		tag, err := parseOpenAPITag(f.Tag.Get("openapi"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+
+		property, err := r.schema(f.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		if containsString(strings.Split(options, ","), "string") && property.Ref == "" {
+			property = JSONSchema{Type: "string", Format: property.Format}
+		}
+		if err = tag.apply(&property, f.Type); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+
+		if schema.Properties == nil {
+			schema.Properties = map[string]JSONSchema{}
+		}
+		schema.Properties[name] = property
+
+		required := !containsString(strings.Split(options, ","), "omitempty")
+		if tag.required != nil {
+			required = *tag.required
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	for _, et := range embedded {
+		if err := r.addFields(schema, et, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openAPITag is the parsed openapi struct tag of a field.
+type openAPITag struct {
+	values   map[string]string
+	required *bool
+}
+
+// parseOpenAPITag parses an openapi struct tag.
+func parseOpenAPITag(tag string) (openAPITag, error) {
+	parsed := openAPITag{values: map[string]string{}}
+	for tag != "" {
+		var item string
+		item, tag = cutTagItem(tag)
+
+		key, value, hasValue := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		switch key {
+		case "":
+			continue
+		case "required", "optional":
+			if hasValue {
+				return openAPITag{}, fmt.Errorf("%s is a flag", key)
+			}
+			required := key == "required"
+			parsed.required = &required
+			continue
+		}
+		if !hasValue {
+			return openAPITag{}, fmt.Errorf("missing value of %s", key)
+		}
+
+		if unquoted, ok := strings.CutPrefix(value, "'"); ok {
+			if value, ok = strings.CutSuffix(unquoted, "'"); !ok {
+				return openAPITag{}, fmt.Errorf("unterminated quoted value of %s", key)
+			}
+		}
+		parsed.values[key] = value
+	}
+
+	return parsed, nil
+}
+
+// cutTagItem returns the first comma separated item of a tag and the rest of the tag,
+// the commas between single quotes not separating the items.
+func cutTagItem(tag string) (string, string) {
+	quoted := false
+	for i, c := range tag {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			return tag[:i], tag[i+1:]
+		}
+	}
+
+	return tag, ""
+}
+
+// apply refines the schema of a field of the given type with the values of the tag,
+// the keys constraining a value being applied to the items of an array.
+func (tag openAPITag) apply(schema *JSONSchema, t reflect.Type) error {
+	array := schema
+	for schema.Items != nil && schema.HasType("array") {
+		schema = schema.Items
+	}
+
+	for _, key := range sortedKeys(tag.values) {
+		value := tag.values[key]
+
+		var err error
+		switch key {
+		case "description":
+			array.Description = value
+		case "title":
+			array.Title = value
+		case "format":
+			schema.Format = value
+		case "pattern":
+			_, err = regexp.Compile(value)
+			schema.Pattern = value
+		case "enum":
+			schema.Enum, err = enumValues(value, t)
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			var f float64
+			if f, err = strconv.ParseFloat(value, 64); err != nil {
+				break
+			}
+			switch key {
+			case "minimum":
+				schema.Minimum = &f
+			case "maximum":
+				schema.Maximum = &f
+			case "exclusiveMinimum":
+				schema.ExclusiveMinimum = f
+			default:
+				schema.ExclusiveMaximum = f
+			}
+		case "minLength", "maxLength", "minItems", "maxItems":
+			var n int
+			if n, err = strconv.Atoi(value); err != nil {
+				break
+			}
+			switch key {
+			case "minLength":
+				schema.MinLength = n
+			case "maxLength":
+				schema.MaxLength = n
+			case "minItems":
+				array.MinItems = n
+			default:
+				array.MaxItems = n
+			}
+		default:
+			err = errors.New("unknown key")
+		}
+		if err != nil {
+			return fmt.Errorf("openapi tag %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// enumValues parses the | separated values of an enum according to the kind of the type of the field,
+// or of its elements.
+func enumValues(value string, t reflect.Type) ([]any, error) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	var values []any
+	for _, s := range strings.Split(value, "|") {
+		var (
+			v   any
+			err error
+		)
+		switch t.Kind() {
+		case reflect.Bool:
+			v, err = strconv.ParseBool(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v, err = strconv.ParseInt(s, 10, 64)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			v, err = strconv.ParseUint(s, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			v, err = strconv.ParseFloat(s, 64)
+		default:
+			v = s
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,347 @@
+package openapiv3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reflectedBase struct {
+	ID        int64     `json:"id" openapi:"minimum=1"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+type reflectedPet struct {
+	reflectedBase
+
+	Name    string            `json:"name" openapi:"description='The name, unique',minLength=1"`
+	Kind    string            `json:"kind,omitempty" openapi:"enum=cat|dog"`
+	Age     *uint8            `json:"age,omitempty" openapi:"maximum=30"`
+	Tags    []string          `json:"tags,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Parent  *reflectedPet     `json:"parent,omitempty"`
+	Weight  float64           `json:"weight,string" openapi:"optional"`
+	Ignored string            `json:"-"`
+	secret  string
+}
+
+type reflectedPage[T any] struct {
+	Items []T `json:"items"`
+	Next  any `json:"next,omitempty"`
+}
+
+type reflectedError struct {
+	Message string `json:"message"`
+}
+
+type reflectedPetParams struct {
+	PetID     int64  `path:"petId" openapi:"description='The pet id'"`
+	Limit     *int32 `query:"limit" openapi:"minimum=1,maximum=100"`
+	RequestID string `header:"X-Request-ID" openapi:"required"`
+}
+
+func TestReflector_Schema(t *testing.T) {
+	tests := []struct {
+		desc               string
+		value              any
+		expected           JSONSchema
+		expectedComponents map[string]Schema
+		expectedAssertion  assert.ErrorAssertionFunc
+	}{
+		{desc: "boolean", value: true, expected: JSONSchema{Type: "boolean"}, expectedAssertion: assert.NoError},
+		{desc: "int32", value: int32(1), expected: JSONSchema{Type: "integer", Format: "int32"}, expectedAssertion: assert.NoError},
+		{
+			desc:              "unsigned integer",
+			value:             uint(1),
+			expected:          JSONSchema{Type: "integer", Format: "int64", Minimum: new(float64)},
+			expectedAssertion: assert.NoError,
+		},
+		{desc: "float", value: float32(1), expected: JSONSchema{Type: "number", Format: "float"}, expectedAssertion: assert.NoError},
+		{desc: "pointer", value: new(string), expected: JSONSchema{Type: "string"}, expectedAssertion: assert.NoError},
+		{desc: "time", value: time.Time{}, expected: JSONSchema{Type: "string", Format: "date-time"}, expectedAssertion: assert.NoError},
+		{desc: "bytes", value: []byte{}, expected: JSONSchema{Type: "string", Format: "byte"}, expectedAssertion: assert.NoError},
+		{
+			desc:              "array",
+			value:             [2]int{},
+			expected:          JSONSchema{Type: "array", Items: &JSONSchema{Type: "integer", Format: "int64"}, MinItems: 2, MaxItems: 2},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "map",
+			value:             map[string]bool{},
+			expected:          JSONSchema{Type: "object", AdditionalProperties: JSONSchema{Type: "boolean"}},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:  "anonymous struct",
+			value: struct{ A string }{},
+			expected: JSONSchema{
+				Type:       "object",
+				Properties: map[string]JSONSchema{"A": {Type: "string"}},
+				Required:   []string{"A"},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:     "named struct",
+			value:    reflectedPet{},
+			expected: JSONSchema{Ref: "#/components/schemas/reflectedPet"},
+			expectedComponents: map[string]Schema{
+				"reflectedPet": {JSONSchema: JSONSchema{
+					Type: "object",
+					Properties: map[string]JSONSchema{
+						"id":        {Type: "integer", Format: "int64", Minimum: float64Ptr(1)},
+						"createdAt": {Type: "string", Format: "date-time"},
+						"name":      {Type: "string", Description: "The name, unique", MinLength: 1},
+						"kind":      {Type: "string", Enum: []any{"cat", "dog"}},
+						"age":       {Type: "integer", Format: "int32", Minimum: new(float64), Maximum: float64Ptr(30)},
+						"tags":      {Type: "array", Items: &JSONSchema{Type: "string"}},
+						"labels":    {Type: "object", AdditionalProperties: JSONSchema{Type: "string"}},
+						"parent":    {Ref: "#/components/schemas/reflectedPet"},
+						"weight":    {Type: "string", Format: "double"},
+					},
+					Required: []string{"name", "id"},
+				}},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:     "generic struct",
+			value:    reflectedPage[reflectedError]{},
+			expected: JSONSchema{Ref: "#/components/schemas/reflectedPagereflectedError"},
+			expectedComponents: map[string]Schema{
+				"reflectedPagereflectedError": {JSONSchema: JSONSchema{
+					Type: "object",
+					Properties: map[string]JSONSchema{
+						"items": {Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/reflectedError"}},
+						"next":  {},
+					},
+					Required: []string{"items"},
+				}},
+				"reflectedError": {JSONSchema: JSONSchema{
+					Type:       "object",
+					Properties: map[string]JSONSchema{"message": {Type: "string"}},
+					Required:   []string{"message"},
+				}},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc: "array constraints",
+			value: struct {
+				Kinds []string `json:"kinds" openapi:"description=Kinds,enum=cat|dog,maxLength=3,minItems=1"`
+				Sizes [][]uint `json:"sizes" openapi:"maximum=9,maxItems=2"`
+			}{},
+			expected: JSONSchema{
+				Type: "object",
+				Properties: map[string]JSONSchema{
+					"kinds": {
+						Type:        "array",
+						Description: "Kinds",
+						MinItems:    1,
+						Items:       &JSONSchema{Type: "string", Enum: []any{"cat", "dog"}, MaxLength: 3},
+					},
+					"sizes": {
+						Type:     "array",
+						MaxItems: 2,
+						Items: &JSONSchema{Type: "array", Items: &JSONSchema{
+							Type: "integer", Format: "int64", Minimum: new(float64), Maximum: float64Ptr(9),
+						}},
+					},
+				},
+				Required: []string{"kinds", "sizes"},
+			},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:  "array of anonymous structs",
+			value: []struct{ A string }{},
+			expected: JSONSchema{Type: "array", Items: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]JSONSchema{"A": {Type: "string"}},
+				Required:   []string{"A"},
+			}},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:  "array of maps",
+			value: []map[string]int32{},
+			expected: JSONSchema{Type: "array", Items: &JSONSchema{
+				Type:                 "object",
+				AdditionalProperties: JSONSchema{Type: "integer", Format: "int32"},
+			}},
+			expectedAssertion: assert.NoError,
+		},
+		{desc: "channel", value: make(chan int), expectedAssertion: assert.Error},
+		{desc: "unsupported map key", value: map[bool]string{}, expectedAssertion: assert.Error},
+		{
+			desc: "unknown tag key",
+			value: struct {
+				A string `openapi:"unknown=1"`
+			}{},
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc: "invalid enum",
+			value: struct {
+				A int `openapi:"enum=1|a"`
+			}{},
+			expectedAssertion: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReflector(&OpenAPI{})
+			schema, err := r.Schema(test.value)
+			test.expectedAssertion(t, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expected, schema.JSONSchema)
+			if test.expectedComponents != nil {
+				require.NotNil(t, r.doc.Components)
+				assert.Equal(t, test.expectedComponents, r.doc.Components.Schemas)
+			}
+		})
+	}
+}
+
+func TestReflector_Schema_validateArray(t *testing.T) {
+	r := NewReflector(&OpenAPI{})
+	schema, err := r.Schema(struct {
+		Kinds []string `json:"kinds" openapi:"enum=cat|dog,maxLength=3"`
+	}{})
+	require.NoError(t, err)
+
+	assert.NoError(t, r.doc.ValidateValue(schema.JSONSchema, map[string]any{"kinds": []any{"cat", "dog"}}))
+	assert.Error(t, r.doc.ValidateValue(schema.JSONSchema, map[string]any{"kinds": []any{"cow"}}))
+}
+
+func TestReflector_AddOperation(t *testing.T) {
+	r := NewReflector(&OpenAPI{Info: Info{Title: "Pets", Version: "1.0.0"}})
+
+	err := r.AddOperation(http.MethodGet, "/pets/{petId}", OperationSpec{
+		OperationID: "showPetById",
+		Tags:        []string{"pets"},
+		Params:      reflectedPetParams{},
+		Responses:   map[string]any{"200": reflectedPet{}, "default": reflectedError{}},
+	})
+	require.NoError(t, err)
+
+	err = r.AddOperation("post", "/pets", OperationSpec{
+		OperationID: "createPet",
+		Body:        reflectedPet{},
+		Responses:   map[string]any{"201": nil},
+	})
+	require.NoError(t, err)
+
+	doc, err := r.OpenAPI()
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.Openapi)
+	assert.ElementsMatch(t, []string{"reflectedPet", "reflectedError"}, sortedKeys(doc.Components.Schemas))
+
+	get := doc.Paths["/pets/{petId}"].Get
+	require.NotNil(t, get)
+	assert.Equal(t, []Parameter{
+		{
+			Name: "petId", In: "path", Description: "The pet id", Required: boolPtr(true),
+			Schema: &Schema{JSONSchema: JSONSchema{Type: "integer", Format: "int64"}},
+		},
+		{
+			Name: "limit", In: "query",
+			Schema: &Schema{JSONSchema: JSONSchema{Type: "integer", Format: "int32", Minimum: float64Ptr(1), Maximum: float64Ptr(100)}},
+		},
+		{Name: "X-Request-ID", In: "header", Required: boolPtr(true), Schema: &Schema{JSONSchema: JSONSchema{Type: "string"}}},
+	}, get.Parameters)
+	assert.Equal(t, Responses{
+		"200": {
+			Description: "OK",
+			Content: map[string]MediaType{
+				"application/json": {Schema: &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/reflectedPet"}}},
+			},
+		},
+		"default": {
+			Description: "Default response",
+			Content: map[string]MediaType{
+				"application/json": {Schema: &Schema{JSONSchema: JSONSchema{Ref: "#/components/schemas/reflectedError"}}},
+			},
+		},
+	}, *get.Responses)
+
+	post := doc.Paths["/pets"].Post
+	require.NotNil(t, post)
+	require.NotNil(t, post.RequestBody)
+	assert.True(t, post.RequestBody.Required)
+	assert.Equal(t, Responses{"201": {Description: "Created"}}, *post.Responses)
+}
+
+func TestReflector_AddOperation_errors(t *testing.T) {
+	tests := []struct {
+		desc          string
+		method        string
+		path          string
+		spec          OperationSpec
+		expectedError string
+	}{
+		{
+			desc:          "missing path parameter",
+			method:        http.MethodGet,
+			path:          "/pets/{id}",
+			expectedError: `GET "/pets/{id}": missing path parameter "id"`,
+		},
+		{
+			desc:          "unknown path parameter",
+			method:        http.MethodGet,
+			path:          "/pets",
+			spec:          OperationSpec{Params: reflectedPetParams{}},
+			expectedError: `GET "/pets": path parameter "petId" is not in the path`,
+		},
+		{
+			desc:          "params not a struct",
+			method:        http.MethodGet,
+			path:          "/pets",
+			spec:          OperationSpec{Params: 1},
+			expectedError: `GET "/pets": params: int is not a struct`,
+		},
+		{
+			desc:          "unsupported method",
+			method:        "CONNECT",
+			path:          "/pets",
+			expectedError: `CONNECT "/pets": unsupported method`,
+		},
+		{
+			desc:          "already registered",
+			method:        http.MethodGet,
+			path:          "/colors",
+			expectedError: `GET "/colors": operation already registered`,
+		},
+		{
+			desc:          "unsupported body",
+			method:        http.MethodPost,
+			path:          "/pets",
+			spec:          OperationSpec{Body: func() {}},
+			expectedError: `POST "/pets": body: unsupported type func()`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReflector(&OpenAPI{})
+			require.NoError(t, r.AddOperation(http.MethodGet, "/colors", OperationSpec{}))
+
+			err := r.AddOperation(test.method, test.path, test.spec)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
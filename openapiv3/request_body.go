@@ -30,6 +30,8 @@ type RequestBody struct {
 	Content map[string]MediaType `json:"content"`
 	// Determines if the request body is required in the request. Defaults to false.
 	Required bool `json:"required"`
+	// Extensions are the Specification Extensions of the request body.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates a RequestBody.
@@ -0,0 +1,166 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveParameter returns the parameter referenced by p, or p itself if it is not a reference.
+// The description defined next to the reference overrides the one of the referenced parameter.
+func (o *OpenAPI) ResolveParameter(p Parameter) (Parameter, error) {
+	return resolveComponent(p, "parameters", o.components().Parameters,
+		func(p *Parameter) *string { return &p.Ref },
+		func(resolved *Parameter, ref Parameter) {
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolveResponse returns the response referenced by r, or r itself if it is not a reference.
+// The description defined next to the reference overrides the one of the referenced response.
+func (o *OpenAPI) ResolveResponse(r Response) (Response, error) {
+	return resolveComponent(r, "responses", o.components().Responses,
+		func(r *Response) *string { return &r.Ref },
+		func(resolved *Response, ref Response) {
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolveRequestBody returns the request body referenced by rb, or rb itself if it is not a reference.
+// The description defined next to the reference overrides the one of the referenced request body.
+func (o *OpenAPI) ResolveRequestBody(rb RequestBody) (RequestBody, error) {
+	return resolveComponent(rb, "requestBodies", o.components().RequestBodies,
+		func(rb *RequestBody) *string { return &rb.Ref },
+		func(resolved *RequestBody, ref RequestBody) {
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolveLink returns the link referenced by l, or l itself if it is not a reference.
+// The description defined next to the reference overrides the one of the referenced link.
+func (o *OpenAPI) ResolveLink(l Link) (Link, error) {
+	return resolveComponent(l, "links", o.components().Links,
+		func(l *Link) *string { return &l.Ref },
+		func(resolved *Link, ref Link) {
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolveExample returns the example referenced by ex, or ex itself if it is not a reference.
+// The summary and the description defined next to the reference override the ones of the referenced example.
+func (o *OpenAPI) ResolveExample(ex Example) (Example, error) {
+	return resolveComponent(ex, "examples", o.components().Examples,
+		func(ex *Example) *string { return &ex.Ref },
+		func(resolved *Example, ref Example) {
+			switch {
+			case ref.Summary != "":
+				resolved.Summary = ref.Summary
+			case ref.Reference.Summary != "":
+				resolved.Summary = ref.Reference.Summary
+			}
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolveHeader returns the header referenced by h, or h itself if it is not a reference.
+// The description defined next to the reference overrides the one of the referenced header.
+func (o *OpenAPI) ResolveHeader(h Header) (Header, error) {
+	return resolveComponent(h, "headers", o.components().Headers,
+		func(h *Header) *string { return &h.Ref },
+		func(resolved *Header, ref Header) {
+			switch {
+			case ref.Description != "":
+				resolved.Description = ref.Description
+			case ref.Reference.Description != "":
+				resolved.Description = ref.Reference.Description
+			}
+		})
+}
+
+// ResolvePathItem returns the path item referenced by pi, or pi itself if it is not a reference.
+// The fields defined next to the reference override the ones of the referenced path item.
+func (o *OpenAPI) ResolvePathItem(pi PathItem) (PathItem, error) {
+	return resolveComponent(pi, "pathItems", o.components().PathItems,
+		func(pi *PathItem) *string { return &pi.Ref },
+		func(resolved *PathItem, ref PathItem) {
+			if ref.Summary != "" {
+				resolved.Summary = ref.Summary
+			}
+			if ref.Description != "" {
+				resolved.Description = ref.Description
+			}
+			for _, mo := range ref.Operations() {
+				_ = resolved.SetOperation(mo.Method, mo.Operation)
+			}
+			if ref.Servers != nil {
+				resolved.Servers = ref.Servers
+			}
+			if ref.Parameters != nil {
+				resolved.Parameters = ref.Parameters
+			}
+		})
+}
+
+// components returns the components of the document, empty if there are none.
+func (o *OpenAPI) components() *Components {
+	if o.Components == nil {
+		return &Components{}
+	}
+
+	return o.Components
+}
+
+// resolveComponent follows the local references to the given components field until an object is found.
+// Once resolved, the fields defined next to the first reference are applied with the override function.
+func resolveComponent[T any](v T, field string, components map[string]T, ref func(*T) *string, override func(resolved *T, ref T)) (T, error) {
+	if *ref(&v) == "" {
+		return v, nil
+	}
+
+	prefix := "#/components/" + field + "/"
+	resolved := v
+	seen := map[string]bool{}
+	for current := *ref(&resolved); current != ""; current = *ref(&resolved) {
+		if !strings.HasPrefix(current, prefix) {
+			return v, fmt.Errorf("unsupported reference %q", current)
+		}
+		if seen[current] {
+			return v, fmt.Errorf("circular reference %q", current)
+		}
+		seen[current] = true
+
+		target, ok := components[strings.TrimPrefix(current, prefix)]
+		if !ok {
+			return v, fmt.Errorf("unresolved reference %q", current)
+		}
+		resolved = target
+	}
+
+	override(&resolved, v)
+
+	return resolved, nil
+}
@@ -76,7 +76,7 @@ type Response struct {
 	// Maps a header name to its definition.
 	// [RFC7230] (https://spec.openapis.org/oas/latest.html#bib-RFC7230) states header names are case insensitive.
 	// If a response header is defined with the name "Content-Type", it SHALL be ignored.
-	Headers map[string]Header
+	Headers map[string]Header `json:"headers,omitempty"`
 	// A map containing descriptions of potential response payloads.
 	// The key is a media type or media type range (https://tools.ietf.org/html/rfc7231#appendix-D) and the value describes it.
 	// For responses that match multiple keys, only the most specific key is applicable.
@@ -86,4 +86,6 @@ type Response struct {
 	// The key of the map is a short name for the link,
 	// following the naming constraints of the names for Component Objects (https://spec.openapis.org/oas/latest.html#componentsObject).
 	Links map[string]Link `json:"links,omitempty"`
+	// Extensions are the Specification Extensions of the response.
+	Extensions Extensions `json:"-"`
 }
@@ -0,0 +1,207 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Runtime expression roots.
+const (
+	ExpressionURL        = "$url"
+	ExpressionMethod     = "$method"
+	ExpressionStatusCode = "$statusCode"
+	ExpressionRequest    = "$request"
+	ExpressionResponse   = "$response"
+)
+
+// Runtime expression sources of the $request and $response roots.
+const (
+	SourceHeader = "header"
+	SourceQuery  = "query"
+	SourcePath   = "path"
+	SourceBody   = "body"
+)
+
+// RuntimeExpression is a [runtime expression] used by the Link parameters and the Callback keys,
+// which allows defining values based on information available within the HTTP message of an actual API call.
+//
+// Examples:
+//
+//	$url
+//	$method
+//	$request.path.id
+//	$request.header.accept
+//	$request.query.queryUrl
+//	$request.body#/user/uuid
+//	$response.body#/status
+//
+// [runtime expression]: https://spec.openapis.org/oas/latest.html#runtime-expressions
+type RuntimeExpression struct {
+	// Raw is the expression as written.
+	Raw string
+	// Root is one of ExpressionURL, ExpressionMethod, ExpressionStatusCode, ExpressionRequest or ExpressionResponse.
+	Root string
+	// Source is one of SourceHeader, SourceQuery, SourcePath or SourceBody, for the $request and $response roots.
+	Source string
+	// Name is the name of the header, query or path parameter.
+	Name string
+	// Pointer is the JSON Pointer into the body, empty for the whole body.
+	Pointer Pointer
+}
+
+func (e RuntimeExpression) String() string {
+	return e.Raw
+}
+
+// ParseRuntimeExpression parses a runtime expression.
+func ParseRuntimeExpression(s string) (RuntimeExpression, error) {
+	expr := RuntimeExpression{Raw: s}
+
+	switch s {
+	case ExpressionURL, ExpressionMethod, ExpressionStatusCode:
+		expr.Root = s
+		return expr, nil
+	}
+
+	root, source, ok := strings.Cut(s, ".")
+	if !ok || root != ExpressionRequest && root != ExpressionResponse {
+		return RuntimeExpression{}, fmt.Errorf("invalid runtime expression %q", s)
+	}
+	expr.Root = root
+
+	if source == SourceBody {
+		expr.Source = SourceBody
+		return expr, nil
+	}
+
+	if fragment, ok := strings.CutPrefix(source, SourceBody+"#"); ok {
+		ptr, err := ParsePointer(fragment)
+		if err != nil {
+			return RuntimeExpression{}, fmt.Errorf("invalid runtime expression %q: %w", s, err)
+		}
+
+		expr.Source = SourceBody
+		expr.Pointer = ptr
+		return expr, nil
+	}
+
+	kind, name, ok := strings.Cut(source, ".")
+	if !ok || name == "" {
+		return RuntimeExpression{}, fmt.Errorf("invalid runtime expression %q: missing name", s)
+	}
+
+	switch kind {
+	case SourceHeader:
+		if !isToken(name) {
+			return RuntimeExpression{}, fmt.Errorf("invalid runtime expression %q: invalid header name %q", s, name)
+		}
+	case SourceQuery, SourcePath:
+	default:
+		return RuntimeExpression{}, fmt.Errorf("invalid runtime expression %q: unknown source %q", s, kind)
+	}
+
+	expr.Source = kind
+	expr.Name = name
+
+	return expr, nil
+}
+
+// isToken reports whether s is a token as defined by [RFC7230], the grammar of the header names.
+//
+// [RFC7230]: https://www.rfc-editor.org/rfc/rfc7230#section-3.2.6
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// ExpressionTemplate is a string embedding runtime expressions in curly braces,
+// like the Callback keys: http://notificationServer.com?transactionId={$request.body#/id}&email={$request.body#/email}.
+type ExpressionTemplate struct {
+	parts []templatePart
+}
+
+// templatePart is either a literal or an expression of a template.
+type templatePart struct {
+	literal    string
+	expression *RuntimeExpression
+}
+
+// ParseExpressionTemplate parses a string embedding runtime expressions.
+// The curly braces not starting with a $ are kept as literal, as the path templates.
+func ParseExpressionTemplate(s string) (ExpressionTemplate, error) {
+	var t ExpressionTemplate
+
+	rest := s
+	for {
+		start := strings.Index(rest, "{$")
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return ExpressionTemplate{}, fmt.Errorf("template %q: unclosed expression", s)
+		}
+		end += start
+
+		expr, err := ParseRuntimeExpression(rest[start+1 : end])
+		if err != nil {
+			return ExpressionTemplate{}, fmt.Errorf("template %q: %w", s, err)
+		}
+
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:start]})
+		}
+		t.parts = append(t.parts, templatePart{expression: &expr})
+		rest = rest[end+1:]
+	}
+
+	if rest != "" {
+		t.parts = append(t.parts, templatePart{literal: rest})
+	}
+
+	return t, nil
+}
+
+// Expressions returns the runtime expressions embedded in the template.
+func (t ExpressionTemplate) Expressions() []RuntimeExpression {
+	var expressions []RuntimeExpression
+	for _, part := range t.parts {
+		if part.expression != nil {
+			expressions = append(expressions, *part.expression)
+		}
+	}
+
+	return expressions
+}
+
+// Expand returns the template where each expression is replaced by its value returned by eval.
+func (t ExpressionTemplate) Expand(eval func(expr RuntimeExpression) (string, error)) (string, error) {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.expression == nil {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		value, err := eval(*part.expression)
+		if err != nil {
+			return "", fmt.Errorf("evaluate %s: %w", part.expression, err)
+		}
+		b.WriteString(value)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,107 @@
+package openapiv3
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRuntimeExpression(t *testing.T) {
+	tests := []struct {
+		desc              string
+		expression        string
+		expected          RuntimeExpression
+		expectedAssertion assert.ErrorAssertionFunc
+	}{
+		{
+			desc:              "url",
+			expression:        "$url",
+			expected:          RuntimeExpression{Raw: "$url", Root: ExpressionURL},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "status code",
+			expression:        "$statusCode",
+			expected:          RuntimeExpression{Raw: "$statusCode", Root: ExpressionStatusCode},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "request path",
+			expression:        "$request.path.id",
+			expected:          RuntimeExpression{Raw: "$request.path.id", Root: ExpressionRequest, Source: SourcePath, Name: "id"},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "request header",
+			expression:        "$request.header.accept",
+			expected:          RuntimeExpression{Raw: "$request.header.accept", Root: ExpressionRequest, Source: SourceHeader, Name: "accept"},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "response body pointer",
+			expression:        "$response.body#/user/uuid",
+			expected:          RuntimeExpression{Raw: "$response.body#/user/uuid", Root: ExpressionResponse, Source: SourceBody, Pointer: Pointer{"user", "uuid"}},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "whole request body",
+			expression:        "$request.body",
+			expected:          RuntimeExpression{Raw: "$request.body", Root: ExpressionRequest, Source: SourceBody},
+			expectedAssertion: assert.NoError,
+		},
+		{
+			desc:              "unknown root",
+			expression:        "$foo",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "unknown source",
+			expression:        "$request.cookie.id",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "missing name",
+			expression:        "$request.query",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "invalid header name",
+			expression:        "$request.header.a b",
+			expectedAssertion: assert.Error,
+		},
+		{
+			desc:              "invalid body pointer",
+			expression:        "$response.body#id",
+			expectedAssertion: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := ParseRuntimeExpression(test.expression)
+			test.expectedAssertion(t, err)
+			assert.Equal(t, test.expected, expr)
+		})
+	}
+}
+
+func TestExpressionTemplate_Expand(t *testing.T) {
+	tmpl, err := ParseExpressionTemplate("http://notificationServer.com/{id}?transactionId={$request.body#/id}&email={$request.body#/email}")
+	require.NoError(t, err)
+
+	require.Len(t, tmpl.Expressions(), 2)
+
+	url, err := tmpl.Expand(func(expr RuntimeExpression) (string, error) {
+		return fmt.Sprintf("<%s>", expr.Pointer), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "http://notificationServer.com/{id}?transactionId=</id>&email=</email>", url)
+
+	_, err = ParseExpressionTemplate("http://example.com?id={$request.body#/id")
+	assert.Error(t, err)
+}
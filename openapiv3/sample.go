@@ -0,0 +1,151 @@
+package openapiv3
+
+import (
+	"math"
+	"strings"
+)
+
+// maxSampleDepth bounds the nesting of the samples of recursive schemas.
+const maxSampleDepth = 8
+
+// SampleValue returns a deterministic value valid against a schema, as it could be used in an example:
+// the example of the referenced component schemas, the first enum value,
+// or a value of the first type of the schema honoring its format and bounds.
+// The allOf subschemas are merged, and the first subschema of the anyOf and oneOf is used.
+func (o *OpenAPI) SampleValue(schema Schema) any {
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	return o.sampleValue(schema.JSONSchema, 0)
+}
+
+func (o *OpenAPI) sampleValue(schema JSONSchema, depth int) any {
+	if depth > maxSampleDepth {
+		return nil
+	}
+
+	if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+		target, ok := o.components().Schemas[name]
+		if !ok {
+			return nil
+		}
+		if target.Example != nil {
+			return target.Example
+		}
+		return o.sampleValue(target.JSONSchema, depth+1)
+	}
+
+	if len(schema.AllOf) > 0 || len(schema.AnyOf) > 0 || len(schema.OneOf) > 0 {
+		// The first schema of the anyOf and oneOf is merged with the schema, as the allOf subschemas.
+		if branches := append(append([]JSONSchema(nil), schema.AnyOf...), schema.OneOf...); len(branches) > 0 {
+			schema.AnyOf, schema.OneOf = nil, nil
+			schema.AllOf = append(append([]JSONSchema(nil), schema.AllOf...), branches[0])
+		}
+		flattened, err := o.flattenSchema(schema)
+		if err != nil {
+			return nil
+		}
+		return o.sampleValue(flattened, depth+1)
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	types := schema.Types()
+	if len(types) == 0 {
+		if len(schema.Properties) > 0 {
+			types = []string{"object"}
+		} else {
+			return nil
+		}
+	}
+
+	switch types[0] {
+	case "object":
+		object := make(map[string]any, len(schema.Properties))
+		for _, name := range sortedKeys(schema.Properties) {
+			if value := o.sampleValue(schema.Properties[name], depth+1); value != nil {
+				object[name] = value
+			}
+		}
+		return object
+	case "array":
+		count := schema.MinItems
+		if count == 0 {
+			count = 1
+		}
+		items := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			items = append(items, o.sampleValue(schema.itemsSchema(), depth+1))
+		}
+		return items
+	case "string":
+		return sampleString(schema)
+	case "integer":
+		return int64(sampleNumber(schema, true))
+	case "number":
+		return sampleNumber(schema, false)
+	case "boolean":
+		return true
+	default:
+		return nil
+	}
+}
+
+// sampleFormats holds the sample values of the well-known string formats.
+var sampleFormats = map[string]string{
+	"date-time": "2006-01-02T15:04:05Z",
+	"date":      "2006-01-02",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"email":     "user@example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com",
+	"hostname":  "example.com",
+}
+
+func sampleString(schema JSONSchema) string {
+	s, ok := sampleFormats[schema.Format]
+	if !ok {
+		s = "string"
+	}
+
+	for len(s) < schema.MinLength {
+		s += "s"
+	}
+	if schema.MaxLength > 0 && len(s) > schema.MaxLength {
+		s = s[:schema.MaxLength]
+	}
+
+	return s
+}
+
+// sampleNumber returns a number within the bounds of the schema, 0 if it is within them.
+func sampleNumber(schema JSONSchema, integer bool) float64 {
+	// step moves the number past the exclusive bounds.
+	step := 0.5
+	if integer {
+		step = 1
+	}
+
+	n := 0.0
+	if schema.Minimum != nil {
+		n = math.Max(n, *schema.Minimum)
+	}
+	if exclusive := schema.exclusiveMinimum(); exclusive != nil && n <= *exclusive {
+		n = *exclusive + step
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		n = *schema.Maximum
+	}
+	if exclusive := schema.exclusiveMaximum(); exclusive != nil && n >= *exclusive {
+		n = *exclusive - step
+	}
+	if integer {
+		n = math.Ceil(n)
+	}
+
+	return n
+}
@@ -41,6 +41,8 @@ type Schema struct {
 	// The example property has been deprecated in favor of the JSON Schema examples keyword.
 	// Use of example is discouraged, and later versions of this specification may remove it.
 	Example any `json:"example,omitempty"`
+	// Extensions are the Specification Extensions of the schema.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates a Schema.
@@ -16,4 +16,4 @@ package openapiv3
 // only one of the Security Requirement Objects in the list needs to be satisfied to authorize the request.
 //
 // [ref]: https://spec.openapis.org/oas/latest.html#security-requirement-object
-type SecurityRequirement map[string]SecurityScheme
+type SecurityRequirement map[string][]string
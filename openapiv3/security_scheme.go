@@ -51,23 +51,25 @@ type SecurityScheme struct {
 	// CommonMark syntax (https://spec.commonmark.org/) MAY be used for rich text representation.
 	Description string `json:"description,omitempty"`
 	// REQUIRED. The name of the header, query or cookie parameter to be used.
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 	// REQUIRED. The location of the API key.
 	// Valid values are "query", "header" or "cookie".
-	In string `json:"in"`
+	In string `json:"in,omitempty"`
 	// REQUIRED.
 	// The name of the HTTP Authorization scheme to be used in the Authorization header as defined in [RFC7235] (https://spec.openapis.org/oas/latest.html#bib-RFC7235).
 	// The values used SHOULD be registered in the IANA Authentication Scheme registry (https://www.iana.org/assignments/http-authschemes/http-authschemes.xhtml).
-	Scheme string `json:"scheme"`
+	Scheme string `json:"scheme,omitempty"`
 	// A hint to the client to identify how the bearer token is formatted.
 	// Bearer tokens are usually generated by an authorization server,
 	// so this information is primarily for documentation purposes.
 	BearerFormat string `json:"bearerFormat,omitempty"`
 	// REQUIRED. An object containing configuration information for the flow types supported.
-	Flows OAuthFlows `json:"flows"`
+	Flows *OAuthFlows `json:"flows,omitempty"`
 	// REQUIRED.
 	// OpenId Connect URL to discover OAuth2 configuration values.
 	// This MUST be in the form of a URL.
 	// The OpenID Connect standard requires the use of TLS.
-	OpenIDConnectURL string `json:"openIdConnectUrl"`
+	OpenIDConnectURL string `json:"openIdConnectUrl,omitempty"`
+	// Extensions are the Specification Extensions of the security scheme.
+	Extensions Extensions `json:"-"`
 }
@@ -43,6 +43,8 @@ type Server struct {
 	Description string `json:"description,omitempty" `
 	// A map between a variable name and its value. The value is used for substitution in the server’s URL template.
 	Variables map[string]ServerVariable `json:"variables,omitempty"`
+	// Extensions are the Specification Extensions of the server.
+	Extensions Extensions `json:"-"`
 }
 
 // Validate validates a Server.
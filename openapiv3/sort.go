@@ -0,0 +1,14 @@
+package openapiv3
+
+import "sort"
+
+// sortedKeys returns the keys of a map in lexical order, to keep any traversal deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,322 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Split writes the document as a tree of files, in the format of the extension of the root file, JSON or YAML:
+//   - components/<field>/<name>: each component, e.g. components/schemas/Pet.yaml;
+//   - paths/<path>: each path item, named after its path without the leading slash, the other slashes
+//     replaced by underscores, e.g. paths/pets_{petId}.yaml for /pets/{petId};
+//   - webhooks/<name>: each webhook;
+//   - the root file: the rest of the document, referencing the other files.
+//
+// The local references are replaced by references relative to the file holding them,
+// and the relative external references are rebased on it.
+// The files can be loaded back into an equivalent document with BundleFile.
+func (o *OpenAPI) Split(root string) error {
+	ext := filepath.Ext(root)
+	switch ext {
+	case ".json", ".yaml", ".yml":
+	default:
+		return fmt.Errorf("unknown file extension %q", ext)
+	}
+
+	doc, err := o.Clone()
+	if err != nil {
+		return err
+	}
+
+	layout := newSplitLayout(filepath.Base(root))
+	for _, field := range componentFields {
+		for _, name := range sortedMapKeys(componentMap(doc.components(), field)) {
+			layout.add(appendPointer("/components", field, name), layout.fileName("components/"+field, name, ext))
+		}
+	}
+	for _, p := range sortedKeys(doc.Paths) {
+		layout.add(appendPointer("/paths", p), layout.fileName("paths", p, ext))
+	}
+	for _, name := range sortedKeys(doc.Webhooks) {
+		layout.add(appendPointer("/webhooks", name), layout.fileName("webhooks", name, ext))
+	}
+
+	rewriteRefs(doc, func(ptr, ref string) string {
+		file, _ := layout.locate(ptr)
+		return layout.splitRef(file, ref)
+	})
+
+	tree, err := toTree(doc)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]any{}
+	for ptr, file := range layout.files {
+		tokens := splitPointer(ptr)
+		parent, _ := lookupTree(tree, Pointer(tokens[:len(tokens)-1]).String())
+		m, _ := parent.(map[string]any)
+		files[file] = m[tokens[len(tokens)-1]]
+		m[tokens[len(tokens)-1]] = map[string]any{"$ref": relativeFile(layout.root, file)}
+	}
+	files[layout.root] = tree
+
+	dir := filepath.Dir(root)
+	for _, file := range sortedKeys(files) {
+		if err = writeTree(filepath.Join(dir, filepath.FromSlash(file)), files[file]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BundleFile loads a document split into several files, see Split.
+// The path items, the webhooks and the components of the root file referencing a whole file are replaced
+// by the content of the file, and the references to the nodes of these files by local references.
+// The other relative external references are rebased on the root file.
+func BundleFile(root string) (*OpenAPI, error) {
+	content, err := readFile(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]any
+	if err = json.Unmarshal(content, &tree); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", filepath.Base(root), err)
+	}
+
+	layout := newSplitLayout(filepath.Base(root))
+	include := func(ptr string, nodes any) error {
+		m, _ := nodes.(map[string]any)
+		for _, key := range sortedKeys(m) {
+			item, _ := m[key].(map[string]any)
+			ref, _ := item["$ref"].(string)
+			if len(item) != 1 || !isFileRef(ref) || strings.Contains(ref, "#") {
+				continue
+			}
+
+			file := path.Clean(unescapeRef(ref))
+			content, err := readFile(filepath.Join(filepath.Dir(root), filepath.FromSlash(file)))
+			if err != nil {
+				return fmt.Errorf("%s: %w", appendPointer(ptr, key), err)
+			}
+
+			var included any
+			if err = json.Unmarshal(content, &included); err != nil {
+				return fmt.Errorf("unmarshal %s: %w", file, err)
+			}
+			m[key] = included
+			layout.add(appendPointer(ptr, key), file)
+		}
+
+		return nil
+	}
+
+	if components, ok := tree["components"].(map[string]any); ok {
+		for _, field := range componentFields {
+			if err = include(appendPointer("/components", field), components[field]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err = include("/paths", tree["paths"]); err != nil {
+		return nil, err
+	}
+	if err = include("/webhooks", tree["webhooks"]); err != nil {
+		return nil, err
+	}
+
+	content, err = json.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("marshal bundle: %w", err)
+	}
+
+	var doc *OpenAPI
+	if err = json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal bundle: %w", err)
+	}
+
+	rewriteRefs(doc, func(ptr, ref string) string {
+		file, _ := layout.locate(ptr)
+		return layout.bundleRef(file, ref)
+	})
+
+	return doc, nil
+}
+
+// splitLayout maps the files of a split document, relative to the directory of its root file,
+// to the pointers of the nodes they hold.
+type splitLayout struct {
+	root     string
+	files    map[string]string
+	pointers map[string]string
+}
+
+func newSplitLayout(root string) *splitLayout {
+	return &splitLayout{root: root, files: map[string]string{}, pointers: map[string]string{root: ""}}
+}
+
+func (l *splitLayout) add(ptr, file string) {
+	l.files[ptr] = file
+	l.pointers[file] = ptr
+}
+
+// fileName returns a file name, not used yet, for a key in a directory.
+func (l *splitLayout) fileName(dir, key, ext string) string {
+	name := strings.ReplaceAll(strings.TrimPrefix(key, "/"), "/", "_")
+	if name == "" {
+		name = "root"
+	}
+
+	file := dir + "/" + name + ext
+	for i := 2; l.used(file); i++ {
+		file = fmt.Sprintf("%s/%s_%d%s", dir, name, i, ext)
+	}
+
+	return file
+}
+
+func (l *splitLayout) used(file string) bool {
+	_, ok := l.pointers[file]
+	return ok
+}
+
+// locate returns the file holding the node at a pointer of the document, and the pointer of the node in the file.
+func (l *splitLayout) locate(ptr string) (string, string) {
+	tokens := splitPointer(ptr)
+	// The files hold the path items and the webhooks, two tokens deep, and the components, three tokens deep.
+	for i := 3; i >= 2; i-- {
+		if len(tokens) < i {
+			continue
+		}
+		if file, ok := l.files[Pointer(tokens[:i]).String()]; ok {
+			return file, Pointer(tokens[i:]).String()
+		}
+	}
+
+	return l.root, ptr
+}
+
+// splitRef returns a reference of the document, held by a file, relative to the file.
+func (l *splitLayout) splitRef(file, ref string) string {
+	switch {
+	case strings.HasPrefix(ref, "#"):
+		target, ptr := l.locate(strings.TrimPrefix(ref, "#"))
+		if target == file {
+			return "#" + ptr
+		}
+		if ptr == "" {
+			return relativeFile(file, target)
+		}
+		return relativeFile(file, target) + "#" + ptr
+	case isFileRef(ref):
+		target, ptr, hasPtr := strings.Cut(ref, "#")
+		target = relativeFile(file, unescapeRef(target))
+		if hasPtr {
+			return target + "#" + ptr
+		}
+		return target
+	default:
+		return ref
+	}
+}
+
+// bundleRef returns a reference held by a file as a reference of the bundled document.
+func (l *splitLayout) bundleRef(file, ref string) string {
+	switch {
+	case strings.HasPrefix(ref, "#"):
+		return "#" + l.pointers[file] + strings.TrimPrefix(ref, "#")
+	case isFileRef(ref):
+		target, ptr, hasPtr := strings.Cut(ref, "#")
+		target = path.Join(path.Dir(file), unescapeRef(target))
+		if l.used(target) {
+			return "#" + l.pointers[target] + ptr
+		}
+		if hasPtr {
+			return escapeRef(target) + "#" + ptr
+		}
+		return escapeRef(target)
+	default:
+		return ref
+	}
+}
+
+// isFileRef reports whether a reference is relative to a JSON or YAML file, e.g. pet.yaml#/properties/name.
+func isFileRef(ref string) bool {
+	file, _, _ := strings.Cut(ref, "#")
+	switch path.Ext(file) {
+	case ".json", ".yaml", ".yml":
+		return !strings.Contains(file, "://") && !path.IsAbs(file)
+	default:
+		return false
+	}
+}
+
+// relativeFile returns a reference to a file relative to another file,
+// both being relative to the same directory.
+func relativeFile(from, to string) string {
+	rel, err := filepath.Rel(filepath.Dir(filepath.FromSlash(from)), filepath.FromSlash(to))
+	if err != nil {
+		return escapeRef(to)
+	}
+
+	return escapeRef(filepath.ToSlash(rel))
+}
+
+// escapeRef percent-encodes the segments of a relative file path to be a URI reference,
+// prefixed with ./ when its first segment would be taken as a URI scheme.
+func escapeRef(file string) string {
+	segments := strings.Split(file, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	ref := strings.Join(segments, "/")
+	if strings.Contains(segments[0], ":") {
+		ref = "./" + ref
+	}
+
+	return ref
+}
+
+// unescapeRef decodes the percent-encoded characters of a file reference, kept as is if it is not encoded.
+func unescapeRef(ref string) string {
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		return unescaped
+	}
+
+	return ref
+}
+
+// writeTree writes a JSON value, decoded as generic Go values, in the format of the extension of the file.
+func writeTree(file string, tree any) error {
+	content, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", file, err)
+	}
+
+	if filepath.Ext(file) != ".json" {
+		if content, err = yaml.JSONToYAML(content); err != nil {
+			return fmt.Errorf("json to yaml %s: %w", file, err)
+		}
+	} else {
+		content = append(content, '\n')
+	}
+
+	if err = os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if err = os.WriteFile(file, content, 0o600); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_Split(t *testing.T) {
+	tests := []struct {
+		file string
+		root string
+	}{
+		{file: "testdata/dereference.yaml", root: "openapi.yaml"},
+		{file: "testdata/dereference.yaml", root: "openapi.json"},
+		{file: "testdata/usage.yaml", root: "openapi.yml"},
+		{file: "testdata/filter.yaml", root: "openapi.yaml"},
+		{file: "testdata/merge_pets.yaml", root: "openapi.json"},
+		{file: "testdata/gooperations.yaml", root: "openapi.yaml"},
+		{file: "testdata/split.yaml", root: "openapi.yaml"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.file+" "+test.root, func(t *testing.T) {
+			t.Parallel()
+
+			oas, err := FromFile(test.file)
+			require.NoError(t, err)
+
+			root := filepath.Join(t.TempDir(), "spec", test.root)
+			require.NoError(t, oas.Split(root))
+
+			bundled, err := BundleFile(root)
+			require.NoError(t, err)
+
+			expected, err := json.Marshal(oas)
+			require.NoError(t, err)
+			actual, err := json.Marshal(bundled)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestOpenAPI_Split_layout(t *testing.T) {
+	oas, err := FromFile("testdata/dereference.yaml")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, oas.Split(filepath.Join(dir, "openapi.json")))
+
+	var files []string
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"components/examples/Pets.json",
+		"components/parameters/Limit.json",
+		"components/pathItems/Pet.json",
+		"components/requestBodies/NewPet.json",
+		"components/responses/Pet.json",
+		"components/responses/Pets.json",
+		"components/responses/PetsAlias.json",
+		"components/schemas/Owner.json",
+		"components/schemas/Pet.json",
+		"components/schemas/Tag.json",
+		"openapi.json",
+		"paths/pets.json",
+		"paths/pets_{petId}.json",
+	}, files)
+
+	read := func(file string) any {
+		t.Helper()
+
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+
+		var tree any
+		require.NoError(t, json.Unmarshal(content, &tree))
+
+		return tree
+	}
+
+	root := read("openapi.json")
+	ref, _ := lookupTree(root, "/paths/~1pets~1{petId}/$ref")
+	assert.Equal(t, "paths/pets_%7BpetId%7D.json", ref)
+	ref, _ = lookupTree(root, "/components/schemas/Pet/$ref")
+	assert.Equal(t, "components/schemas/Pet.json", ref)
+
+	pets := read("paths/pets.json")
+	ref, _ = lookupTree(pets, "/get/parameters/0/$ref")
+	assert.Equal(t, "../components/parameters/Limit.json", ref)
+	ref, _ = lookupTree(pets, "/get/responses/default/$ref")
+	assert.Equal(t, "../other.yaml#/components/responses/Error", ref)
+
+	pet := read("components/schemas/Pet.json")
+	ref, _ = lookupTree(pet, "/properties/tags/items/$ref")
+	assert.Equal(t, "Tag.json", ref)
+
+	ref, _ = lookupTree(read("paths/pets_{petId}.json"), "/$ref")
+	assert.Equal(t, "../components/pathItems/Pet.json", ref)
+}
+
+func TestEscapeRef(t *testing.T) {
+	tests := map[string]string{
+		"paths/pets.yaml":        "paths/pets.yaml",
+		"paths/a%20b.yaml":       "paths/a%2520b.yaml",
+		"paths/c?x.yaml":         "paths/c%3Fx.yaml",
+		"../schemas/Pet#1.yaml":  "../schemas/Pet%231.yaml",
+		"urn:pets.yaml":          "./urn:pets.yaml",
+		"paths/pets_{petId}.yml": "paths/pets_%7BpetId%7D.yml",
+	}
+
+	for file, expected := range tests {
+		assert.Equal(t, expected, escapeRef(file), file)
+		assert.Equal(t, file, strings.TrimPrefix(unescapeRef(expected), "./"), file)
+	}
+}
+
+func TestOpenAPI_Split_unknownExtension(t *testing.T) {
+	oas := &OpenAPI{Openapi: "3.1.0", Info: Info{Title: "API", Version: "1.0.0"}}
+
+	err := oas.Split(filepath.Join(t.TempDir(), "openapi.txt"))
+	assert.EqualError(t, err, `unknown file extension ".txt"`)
+}
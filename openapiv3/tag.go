@@ -21,5 +21,7 @@ type Tag struct {
 	// CommonMark syntax (https://spec.commonmark.org/) MAY be used for rich text representation.
 	Description string `json:"description,omitempty"`
 	// Additional external documentation for this tag.
-	ExternalDocs ExternalDocumentation `json:"externalDocs,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	// Extensions are the Specification Extensions of the tag.
+	Extensions Extensions `json:"-"`
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// main calls operations of a test server with various parameters and credentials, and prints the requests
+// the server receives, with their credentials, along with the responses.
+func main() {
+	var request string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := []string{r.Method, r.RequestURI}
+		for _, name := range []string{"Authorization", "X-Api-Key", "X-Request-Id", "Cookie"} {
+			if value := r.Header.Get(name); value != "" {
+				fields = append(fields, name+"="+value)
+			}
+		}
+		request = strings.Join(fields, " ")
+
+		switch {
+		case r.URL.Path == "/pets/404":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"not found"}`)
+		case strings.HasPrefix(r.URL.Path, "/pets/") && r.URL.Path != "/pets/mine":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id":42,"name":"Rex"}`)
+		case strings.HasPrefix(r.URL.Path, "/pets"):
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Next", "/pets?page=2")
+			fmt.Fprint(w, `[]`)
+		case strings.HasPrefix(r.URL.Path, "/colors/"):
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "colors")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	limit := int32(10)
+	status := StatusSold
+	name, minAge, x, y := "rex", 2, 1, 2
+
+	calls := []func(c *Client) (any, error){
+		func(c *Client) (any, error) {
+			c.BearerAuth, c.APIKey = "token", "key"
+			return c.ListPets(ctx, ListPetsParams{
+				Limit:      &limit,
+				Tags:       []string{"a", "b c"},
+				Status:     &status,
+				Filter:     &Filter{Name: &name, MinAge: &minAge},
+				XRequestID: "id",
+			})
+		},
+		func(c *Client) (any, error) {
+			c.APIKey = "key"
+			return c.ListPets(ctx, ListPetsParams{XRequestID: "id"})
+		},
+		func(c *Client) (any, error) {
+			return c.ListPets(ctx, ListPetsParams{XRequestID: "id"})
+		},
+		func(c *Client) (any, error) {
+			c.BasicAuth, c.SessionKey = &BasicCredentials{Username: "user", Password: "pass"}, "session"
+			return c.ListMyPets(ctx, ListMyPetsParams{Session: "abc"})
+		},
+		func(c *Client) (any, error) {
+			c.BasicAuth = &BasicCredentials{Username: "user", Password: "pass"}
+			return c.ListMyPets(ctx, ListMyPetsParams{Session: "abc"})
+		},
+		func(c *Client) (any, error) {
+			c.BearerAuth = "token"
+			return c.ShowPetByID(ctx, ShowPetByIDParams{PetID: 42})
+		},
+		func(c *Client) (any, error) {
+			return c.ShowPetByID(ctx, ShowPetByIDParams{PetID: 404})
+		},
+		func(c *Client) (any, error) {
+			c.BearerAuth = "token"
+			return c.GetColors(ctx, GetColorsParams{
+				Colors: []GetColorsColorsItem{GetColorsColorsItemRed, GetColorsColorsItemBlue},
+				Point:  &GetColorsPoint{X: &x, Y: &y},
+			})
+		},
+		func(c *Client) (any, error) {
+			return c.GetSizes(ctx, GetSizesParams{Sizes: []int{1, 2}})
+		},
+	}
+
+	for _, call := range calls {
+		request = ""
+		response, err := call(NewClient(srv.URL))
+		if err != nil {
+			fmt.Println(request, "error:", err)
+			continue
+		}
+		fmt.Println(request, describe(response))
+	}
+}
+
+// describe returns the type and the fields of a response, its pointers and bytes being formatted as their value.
+func describe(response any) string {
+	switch r := response.(type) {
+	case ListPets200Response:
+		return fmt.Sprintf("ListPets200Response{XNext:%s Body:%v}", *r.XNext, r.Body)
+	case GetColors200Response:
+		return fmt.Sprintf("GetColors200Response{Body:%s}", r.Body)
+	default:
+		return strings.TrimPrefix(fmt.Sprintf("%T%+v", response, response), "main.")
+	}
+}
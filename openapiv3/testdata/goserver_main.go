@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+// server records the parameters of the operations it serves.
+type server struct {
+	params any
+}
+
+func (s *server) GetColors(_ context.Context, params GetColorsParams) (GetColorsResponse, error) {
+	s.params = params
+	return GetColors200Response{Body: []byte("colors")}, nil
+}
+
+func (s *server) ListPets(_ context.Context, params ListPetsParams) (ListPetsResponse, error) {
+	s.params = params
+	return ListPets200Response{Body: Pets{}}, nil
+}
+
+func (s *server) CreatePet(context.Context, NewPet) (CreatePetResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *server) ListMyPets(context.Context, ListMyPetsParams) (ListMyPetsResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *server) ShowPetByID(_ context.Context, params ShowPetByIDParams) (ShowPetByIDResponse, error) {
+	s.params = params
+	return ShowPetByID200Response{Body: Pet{ID: params.PetID, Name: "Rex"}}, nil
+}
+
+func (s *server) DeletePetsPetID(context.Context, DeletePetsPetIDParams) (DeletePetsPetIDResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *server) UploadPhoto(context.Context, UploadPhotoParams, []byte) (UploadPhotoResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *server) GetSizes(_ context.Context, params GetSizesParams) (GetSizesResponse, error) {
+	s.params = params
+	return GetSizes204Response{}, nil
+}
+
+// main serves a GET request per target, and prints the status code with the parameters given to the server,
+// or with the error.
+func main() {
+	for _, target := range os.Args[1:] {
+		s := &server{}
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-Request-ID", "id")
+		rec := httptest.NewRecorder()
+		NewHandler(s).ServeHTTP(rec, req)
+
+		if rec.Code >= 400 {
+			fmt.Println(rec.Code, strings.TrimSpace(rec.Body.String()))
+			continue
+		}
+		params, err := json.Marshal(s.params)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(rec.Code, string(params))
+	}
+}
@@ -0,0 +1,71 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// toTree converts a value into its JSON representation decoded as generic Go values.
+func toTree(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	var tree any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return tree, nil
+}
+
+// copyTree deep copies a JSON value decoded as generic Go values.
+func copyTree(node any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, value := range v {
+			c[key] = copyTree(value)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, value := range v {
+			c[i] = copyTree(value)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// lookupTree returns the node of a JSON value, decoded as generic Go values, at the given JSON Pointer.
+func lookupTree(node any, ptr string) (any, bool) {
+	if ptr != "" && !strings.HasPrefix(ptr, "/") {
+		return nil, false
+	}
+
+	for _, token := range splitPointer(ptr) {
+		switch v := node.(type) {
+		case map[string]any:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			node = child
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
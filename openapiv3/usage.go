@@ -0,0 +1,151 @@
+package openapiv3
+
+import "strings"
+
+// usedComponents returns the local references of the components used by the document, e.g. #/components/schemas/Pet:
+// the components reachable from its paths, webhooks, security requirements and callbacks,
+// directly or through other components.
+// The security schemes are used by the security requirements naming them.
+func (o *OpenAPI) usedComponents() map[string]bool {
+	used := map[string]bool{}
+
+	w := &Walker{
+		FollowRefs: true,
+		Enter: func(node *WalkNode) error {
+			if node.Ref != "" {
+				used[node.Ref] = true
+			}
+
+			if node.Parent != nil {
+				if _, ok := node.Parent.Value.(*Components); ok {
+					// The components are only walked when they are referenced, except the callbacks:
+					// the references to them are not supported, so they are always used.
+					if !strings.HasPrefix(node.Pointer, "/components/callbacks/") {
+						return SkipNode
+					}
+					used["#"+node.Pointer] = true
+				}
+			}
+
+			switch v := node.Value.(type) {
+			case *SecurityRequirement:
+				for name := range *v {
+					used[componentRef("securitySchemes", name)] = true
+				}
+			case *Schema:
+				if v.Discriminator != nil {
+					for _, ref := range v.Discriminator.Mapping {
+						used[ref] = true
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+	// The hook does not fail.
+	_ = w.Walk(o)
+
+	return used
+}
+
+// UsedComponents returns the local references of the components reachable from the paths, the webhooks,
+// the security requirements and the callbacks of the document, directly or through other components,
+// e.g. #/components/schemas/Pet, sorted by kind, in the order of the specification, then by name.
+// The security schemes are used by the security requirements naming them,
+// and the schemas by the discriminator mappings of the used schemas.
+// The callbacks are always used, as the references to them are not supported.
+func (o *OpenAPI) UsedComponents() []string {
+	used := o.usedComponents()
+
+	var refs []string
+	for _, ref := range o.components().refs() {
+		if used[ref] {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// UnusedComponents returns the local references of the components that are not used, see UsedComponents,
+// sorted by kind, in the order of the specification, then by name.
+func (o *OpenAPI) UnusedComponents() []string {
+	used := o.usedComponents()
+
+	var unused []string
+	for _, ref := range o.components().refs() {
+		if !used[ref] {
+			unused = append(unused, ref)
+		}
+	}
+
+	return unused
+}
+
+// PruneComponents removes the unused components of the document, see UnusedComponents,
+// and returns their local references.
+func (o *OpenAPI) PruneComponents() []string {
+	unused := o.UnusedComponents()
+	for _, ref := range unused {
+		o.Components.remove(ref)
+	}
+
+	return unused
+}
+
+// refs returns the local references of the components, sorted by kind, in the order of the specification,
+// then by name.
+func (c *Components) refs() []string {
+	var refs []string
+	add := func(field string, names []string) {
+		for _, name := range names {
+			refs = append(refs, componentRef(field, name))
+		}
+	}
+
+	add("schemas", sortedKeys(c.Schemas))
+	add("responses", sortedKeys(c.Responses))
+	add("parameters", sortedKeys(c.Parameters))
+	add("examples", sortedKeys(c.Examples))
+	add("requestBodies", sortedKeys(c.RequestBodies))
+	add("headers", sortedKeys(c.Headers))
+	add("securitySchemes", sortedKeys(c.SecuritySchemes))
+	add("links", sortedKeys(c.Links))
+	add("callbacks", sortedKeys(c.Callbacks))
+	add("pathItems", sortedKeys(c.PathItems))
+
+	return refs
+}
+
+// remove removes the component of a local reference.
+func (c *Components) remove(ref string) {
+	tokens := splitPointer(strings.TrimPrefix(ref, "#"))
+	if len(tokens) != 3 || tokens[0] != "components" {
+		return
+	}
+
+	name := tokens[2]
+	switch tokens[1] {
+	case "schemas":
+		delete(c.Schemas, name)
+	case "responses":
+		delete(c.Responses, name)
+	case "parameters":
+		delete(c.Parameters, name)
+	case "examples":
+		delete(c.Examples, name)
+	case "requestBodies":
+		delete(c.RequestBodies, name)
+	case "headers":
+		delete(c.Headers, name)
+	case "securitySchemes":
+		delete(c.SecuritySchemes, name)
+	case "links":
+		delete(c.Links, name)
+	case "callbacks":
+		delete(c.Callbacks, name)
+	case "pathItems":
+		delete(c.PathItems, name)
+	}
+}
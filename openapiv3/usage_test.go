@@ -0,0 +1,67 @@
+package openapiv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var usageUnusedComponents = []string{
+	"#/components/schemas/Unused",
+	"#/components/schemas/UsedByUnused",
+	"#/components/responses/Unused",
+	"#/components/parameters/Unused",
+	"#/components/examples/Unused",
+	"#/components/requestBodies/Unused",
+	"#/components/headers/Unused",
+	"#/components/securitySchemes/oauth",
+	"#/components/links/Unused",
+	"#/components/pathItems/Unused",
+}
+
+func TestOpenAPI_UsedComponents(t *testing.T) {
+	oas, err := FromFile("testdata/usage.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"#/components/schemas/Attribute",
+		"#/components/schemas/Dog",
+		"#/components/schemas/Event",
+		"#/components/schemas/Owner",
+		"#/components/schemas/Pet",
+		"#/components/responses/Pets",
+		"#/components/parameters/Limit",
+		"#/components/examples/Pets",
+		"#/components/requestBodies/NewPet",
+		"#/components/headers/RateLimit",
+		"#/components/securitySchemes/apiKey",
+		"#/components/links/First",
+		"#/components/callbacks/onEvent",
+		"#/components/pathItems/Pets",
+	}, oas.UsedComponents())
+	assert.Equal(t, usageUnusedComponents, oas.UnusedComponents())
+}
+
+func TestOpenAPI_PruneComponents(t *testing.T) {
+	oas, err := FromFile("testdata/usage.yaml")
+	require.NoError(t, err)
+
+	used := oas.UsedComponents()
+
+	assert.Equal(t, usageUnusedComponents, oas.PruneComponents())
+	assert.Equal(t, used, oas.UsedComponents())
+	assert.Empty(t, oas.UnusedComponents())
+	assert.Contains(t, oas.Components.Callbacks, "onEvent")
+	assert.NoError(t, oas.Validate())
+
+	assert.Empty(t, oas.PruneComponents())
+}
+
+func TestOpenAPI_PruneComponents_noComponents(t *testing.T) {
+	oas := &OpenAPI{Openapi: "3.1.0", Info: Info{Title: "Pets", Version: "1.0.0"}}
+
+	assert.Empty(t, oas.UsedComponents())
+	assert.Empty(t, oas.PruneComponents())
+	assert.Nil(t, oas.Components)
+}
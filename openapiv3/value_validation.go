@@ -0,0 +1,352 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValueError is a violation of a schema by a value.
+type ValueError struct {
+	// Pointer is the JSON Pointer of the invalid value, empty for the whole value.
+	Pointer string
+	Message string
+}
+
+func (e *ValueError) Error() string {
+	if e.Pointer == "" {
+		return e.Message
+	}
+
+	return e.Pointer + ": " + e.Message
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateValue validates a value, as decoded from JSON, against a schema.
+// The local references to the component schemas are resolved within the document,
+// the unknown formats are ignored.
+// All the violations are returned, joined, as ValueError.
+func (o *OpenAPI) ValidateValue(schema JSONSchema, value any) error {
+	v := valueValidator{doc: o}
+	v.validate(schema, value, "")
+
+	return errors.Join(v.errs...)
+}
+
+// ValidatePayload decodes a JSON payload and validates it against a schema, see ValidateValue.
+func (o *OpenAPI) ValidatePayload(schema JSONSchema, payload []byte) error {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+
+	return o.ValidateValue(schema, value)
+}
+
+type valueValidator struct {
+	doc  *OpenAPI
+	errs []error
+}
+
+func (v *valueValidator) fail(ptr, format string, args ...any) {
+	v.errs = append(v.errs, &ValueError{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *valueValidator) validate(schema JSONSchema, value any, ptr string) {
+	schema, err := v.resolve(schema)
+	if err != nil {
+		v.fail(ptr, "%v", err)
+		return
+	}
+
+	v.validateComposition(schema, value, ptr)
+
+	if !v.validateType(schema, value, ptr) {
+		return
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		v.fail(ptr, "value %v is not one of %v", value, schema.Enum)
+	}
+
+	switch val := value.(type) {
+	case string:
+		v.validateString(schema, val, ptr)
+	case []any:
+		v.validateArray(schema, val, ptr)
+	case map[string]any:
+		v.validateObject(schema, val, ptr)
+	default:
+		if n, ok := toFloat(value); ok {
+			v.validateNumber(schema, n, ptr)
+		}
+	}
+}
+
+// resolve follows the references to the component schemas.
+func (v *valueValidator) resolve(schema JSONSchema) (JSONSchema, error) {
+	const prefix = "#/components/schemas/"
+
+	seen := map[string]bool{}
+	for schema.Ref != "" {
+		name, ok := strings.CutPrefix(schema.Ref, prefix)
+		if !ok {
+			return schema, fmt.Errorf("unsupported reference %q", schema.Ref)
+		}
+		if seen[name] {
+			return schema, fmt.Errorf("circular reference %q", schema.Ref)
+		}
+		seen[name] = true
+
+		target, ok := v.doc.components().Schemas[name]
+		if !ok {
+			return schema, fmt.Errorf("unresolved reference %q", schema.Ref)
+		}
+		schema = target.JSONSchema
+	}
+
+	return schema, nil
+}
+
+// validateComposition validates the value against the allOf, anyOf, oneOf and not subschemas.
+func (v *valueValidator) validateComposition(schema JSONSchema, value any, ptr string) {
+	for _, subschema := range schema.AllOf {
+		v.validate(subschema, value, ptr)
+	}
+
+	if len(schema.AnyOf) > 0 && v.countMatches(schema.AnyOf, value, ptr) == 0 {
+		v.fail(ptr, "value does not match any schema of anyOf")
+	}
+
+	if len(schema.OneOf) > 0 {
+		switch n := v.countMatches(schema.OneOf, value, ptr); n {
+		case 0:
+			v.fail(ptr, "value does not match any schema of oneOf")
+		case 1:
+		default:
+			v.fail(ptr, "value matches %d schemas of oneOf instead of one", n)
+		}
+	}
+
+	if schema.Not != nil && v.countMatches([]JSONSchema{*schema.Not}, value, ptr) > 0 {
+		v.fail(ptr, "value must not match the schema of not")
+	}
+}
+
+// countMatches returns the number of schemas the value is valid against.
+func (v *valueValidator) countMatches(schemas []JSONSchema, value any, ptr string) int {
+	var n int
+	for _, schema := range schemas {
+		sub := valueValidator{doc: v.doc}
+		sub.validate(schema, value, ptr)
+		if len(sub.errs) == 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// validateType reports whether the value matches one of the types of the schema, if any.
+func (v *valueValidator) validateType(schema JSONSchema, value any, ptr string) bool {
+	types := schema.Types()
+	if len(types) == 0 {
+		return true
+	}
+
+	kind := valueType(value)
+	for _, t := range types {
+		if t == kind || t == "number" && kind == "integer" {
+			return true
+		}
+	}
+
+	v.fail(ptr, "%s is not of type %s", kind, strings.Join(types, ", "))
+
+	return false
+}
+
+func (v *valueValidator) validateString(schema JSONSchema, s string, ptr string) {
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength > 0 && length < schema.MinLength {
+		v.fail(ptr, "length %d is less than %d", length, schema.MinLength)
+	}
+	if schema.MaxLength > 0 && length > schema.MaxLength {
+		v.fail(ptr, "length %d is greater than %d", length, schema.MaxLength)
+	}
+
+	if schema.Pattern != "" {
+		re, err := regexp.Compile(schema.Pattern)
+		switch {
+		case err != nil:
+			v.fail(ptr, "invalid pattern %q: %v", schema.Pattern, err)
+		case !re.MatchString(s):
+			v.fail(ptr, "%q does not match pattern %q", s, schema.Pattern)
+		}
+	}
+
+	if schema.Format != "" && !validFormat(schema.Format, s) {
+		v.fail(ptr, "%q is not a valid %s", s, schema.Format)
+	}
+}
+
+func (v *valueValidator) validateNumber(schema JSONSchema, n float64, ptr string) {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		v.fail(ptr, "%v is less than %v", n, *schema.Minimum)
+	}
+	if exclusive := schema.exclusiveMinimum(); exclusive != nil && n <= *exclusive {
+		v.fail(ptr, "%v is less than or equal to %v", n, *exclusive)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		v.fail(ptr, "%v is greater than %v", n, *schema.Maximum)
+	}
+	if exclusive := schema.exclusiveMaximum(); exclusive != nil && n >= *exclusive {
+		v.fail(ptr, "%v is greater than or equal to %v", n, *exclusive)
+	}
+}
+
+func (v *valueValidator) validateArray(schema JSONSchema, items []any, ptr string) {
+	if schema.MinItems > 0 && len(items) < schema.MinItems {
+		v.fail(ptr, "%d items are less than %d", len(items), schema.MinItems)
+	}
+	if schema.MaxItems > 0 && len(items) > schema.MaxItems {
+		v.fail(ptr, "%d items are more than %d", len(items), schema.MaxItems)
+	}
+
+	if schema.Items == nil {
+		return
+	}
+
+	for i, item := range items {
+		v.validate(schema.itemsSchema(), item, appendPointer(ptr, strconv.Itoa(i)))
+	}
+}
+
+func (v *valueValidator) validateObject(schema JSONSchema, object map[string]any, ptr string) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			v.fail(ptr, "missing required property %q", name)
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		if value, ok := object[name]; ok {
+			v.validate(schema.Properties[name], value, appendPointer(ptr, name))
+		}
+	}
+
+	additional, allowed := schema.AdditionalPropertiesSchema()
+	if allowed && additional == nil {
+		return
+	}
+	for _, name := range sortedKeys(object) {
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+		if !allowed {
+			v.fail(ptr, "additional property %q is not allowed", name)
+			continue
+		}
+		v.validate(*additional, object[name], appendPointer(ptr, name))
+	}
+}
+
+// valueType returns the JSON Schema type of a value.
+func valueType(value any) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		n, ok := toFloat(val)
+		if !ok {
+			return fmt.Sprintf("%T", value)
+		}
+		if n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+}
+
+// toFloat returns the value of a number as a float64.
+func toFloat(value any) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// enumContains reports whether the value is one of the enum values, compared by their JSON representation.
+func enumContains(enum []any, value any) bool {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range enum {
+		if candidate, err := json.Marshal(e); err == nil && string(candidate) == string(raw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validFormat reports whether a string is valid for a format, the unknown formats being always valid.
+func validFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	default:
+		return true
+	}
+}
@@ -0,0 +1,182 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_ValidatePayload(t *testing.T) {
+	oas := &OpenAPI{
+		Components: &Components{
+			Schemas: map[string]Schema{
+				"Pet": {JSONSchema: JSONSchema{
+					Type:     "object",
+					Required: []string{"id", "name"},
+					Properties: map[string]JSONSchema{
+						"id":     {Type: "integer", Minimum: float64Ptr(1)},
+						"name":   {Type: "string", MinLength: 1, MaxLength: 5, Pattern: "^[A-Z]"},
+						"kind":   {Type: "string", Enum: []any{"cat", "dog"}},
+						"weight": {Type: []any{"number", "null"}, ExclusiveMaximum: 100.0},
+						"tags":   {Type: "array", MaxItems: 2, Items: &JSONSchema{Type: "string"}},
+						"owner":  {Ref: "#/components/schemas/Owner"},
+					},
+				}},
+				"Owner": {JSONSchema: JSONSchema{
+					Type: "object",
+					Properties: map[string]JSONSchema{
+						"email":   {Type: "string", Format: "email"},
+						"since":   {Type: "string", Format: "date-time"},
+						"id":      {Type: "string", Format: "uuid"},
+						"address": {Type: "string", Format: "ipv4"},
+						"custom":  {Type: "string", Format: "custom"},
+					},
+				}},
+			},
+		},
+	}
+
+	schema := JSONSchema{Ref: "#/components/schemas/Pet"}
+
+	tests := []struct {
+		desc     string
+		payload  string
+		expected []string
+	}{
+		{
+			desc:    "valid",
+			payload: `{"id":1,"name":"Rex","kind":"dog","weight":null,"tags":["a"],"owner":{"email":"bob@example.com","since":"2023-01-02T15:04:05Z","id":"0b1c3a7e-5f0e-4b0e-9d1e-0c6b2f8f9a11","address":"10.0.0.1","custom":"x"}}`,
+		},
+		{
+			desc:     "not an object",
+			payload:  `[]`,
+			expected: []string{"array is not of type object"},
+		},
+		{
+			desc:    "invalid properties",
+			payload: `{"id":0.5,"name":"rexford","kind":"bird","weight":100,"tags":["a","b",3]}`,
+			expected: []string{
+				"/id: number is not of type integer",
+				`/kind: value bird is not one of [cat dog]`,
+				"/name: length 7 is greater than 5",
+				`/name: "rexford" does not match pattern "^[A-Z]"`,
+				"/tags: 3 items are more than 2",
+				"/tags/2: integer is not of type string",
+				"/weight: 100 is greater than or equal to 100",
+			},
+		},
+		{
+			desc:     "missing required properties",
+			payload:  `{"id":0}`,
+			expected: []string{`missing required property "name"`, "/id: 0 is less than 1"},
+		},
+		{
+			desc:    "invalid formats",
+			payload: `{"id":1,"name":"Rex","owner":{"email":"Bob <bob@example.com>","since":"2023-01-02","id":"0b1c3a7e","address":"::1"}}`,
+			expected: []string{
+				`/owner/address: "::1" is not a valid ipv4`,
+				`/owner/email: "Bob <bob@example.com>" is not a valid email`,
+				`/owner/id: "0b1c3a7e" is not a valid uuid`,
+				`/owner/since: "2023-01-02" is not a valid date-time`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := oas.ValidatePayload(schema, []byte(test.payload))
+			if len(test.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var messages []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				assert.IsType(t, &ValueError{}, e)
+				messages = append(messages, e.Error())
+			}
+			assert.Equal(t, test.expected, messages)
+		})
+	}
+}
+
+func TestOpenAPI_ValidateValue_composition(t *testing.T) {
+	oas := &OpenAPI{}
+
+	schema := JSONSchema{
+		AllOf: []JSONSchema{{Type: "object", Required: []string{"kind"}}},
+		OneOf: []JSONSchema{
+			{Properties: map[string]JSONSchema{"kind": {Enum: []any{"cat"}}}},
+			{Properties: map[string]JSONSchema{"kind": {Type: "string", MinLength: 3}}},
+		},
+		AnyOf: []JSONSchema{{Required: []string{"name"}}, {Required: []string{"id"}}},
+		Not:   &JSONSchema{Required: []string{"deleted"}},
+	}
+
+	tests := []struct {
+		desc     string
+		value    any
+		expected string
+	}{
+		{desc: "valid", value: map[string]any{"kind": "dog", "id": 1}},
+		{
+			desc:     "allOf",
+			value:    map[string]any{"id": 1},
+			expected: "missing required property \"kind\"\nvalue matches 2 schemas of oneOf instead of one",
+		},
+		{
+			desc:     "oneOf matching several schemas",
+			value:    map[string]any{"kind": "cat", "id": 1},
+			expected: "value matches 2 schemas of oneOf instead of one",
+		},
+		{
+			desc:     "oneOf matching no schema",
+			value:    map[string]any{"kind": "ox", "id": 1},
+			expected: "value does not match any schema of oneOf",
+		},
+		{
+			desc:     "anyOf",
+			value:    map[string]any{"kind": "dog"},
+			expected: "value does not match any schema of anyOf",
+		},
+		{
+			desc:     "not",
+			value:    map[string]any{"kind": "dog", "id": 1, "deleted": true},
+			expected: "value must not match the schema of not",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := oas.ValidateValue(schema, test.value)
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestOpenAPI_ValidateValue_exclusiveFlags(t *testing.T) {
+	var schema JSONSchema
+	err := json.Unmarshal([]byte(`{"type": "integer", "minimum": 1, "exclusiveMinimum": true, "maximum": 5, "exclusiveMaximum": false}`), &schema)
+	require.NoError(t, err)
+
+	oas := &OpenAPI{Openapi: "3.0.3"}
+	assert.NoError(t, oas.ValidateValue(schema, 5))
+	assert.EqualError(t, oas.ValidateValue(schema, 1), "1 is less than or equal to 1")
+}
+
+func TestOpenAPI_ValidateValue_unresolvedReference(t *testing.T) {
+	err := (&OpenAPI{}).ValidateValue(JSONSchema{Ref: "#/components/schemas/Unknown"}, "value")
+	assert.EqualError(t, err, `unresolved reference "#/components/schemas/Unknown"`)
+}
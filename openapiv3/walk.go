@@ -0,0 +1,494 @@
+package openapiv3
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SkipNode is used as a return value from the enter hooks of a Walker
+// to indicate that the children of the visited node are to be skipped.
+// It is not returned as an error by any function.
+var SkipNode = errors.New("skip this node")
+
+// WalkNode is a node of an OpenAPI document visited by a Walker.
+type WalkNode struct {
+	// Pointer is the JSON Pointer of the node in the document.
+	Pointer string
+	// Value is a pointer to the visited object: *OpenAPI, *Info, *Server, *PathItem, *Operation, *Parameter,
+	// *Header, *RequestBody, *Response, *MediaType, *Encoding, *Example, *Link, *Callback, *Components,
+	// *Schema, *JSONSchema (the items, the properties, the additional properties and the subschemas of a schema),
+	// *SecurityScheme, *SecurityRequirement, *Tag or *ExternalDocumentation.
+	// The changes made to the object are applied to the document.
+	Value any
+	// Parent is the node containing this node, nil for the document itself.
+	// When a reference is followed, the parent of the referenced node is the node holding the reference.
+	Parent *WalkNode
+	// Ref is the reference followed to reach this node, empty if the node has been reached directly.
+	Ref string
+}
+
+// Walker traverses an OpenAPI document and calls hooks on each visited node.
+//
+// The hooks for a specific object type are registered with OnEnter and OnLeave.
+// An enter hook can return SkipNode to skip the children of a node, in which case the leave hooks are not called.
+// Any other error stops the walk and is returned by Walk.
+type Walker struct {
+	// FollowRefs enables the traversal of the local references (#/components/...) as if their target was inlined.
+	// A reference leading to a cycle is not followed.
+	FollowRefs bool
+	// Enter is called on every node before its children.
+	Enter func(node *WalkNode) error
+	// Leave is called on every node after its children.
+	Leave func(node *WalkNode) error
+
+	enter     map[reflect.Type][]func(node *WalkNode) error
+	leave     map[reflect.Type][]func(node *WalkNode) error
+	doc       *OpenAPI
+	following []string
+	// ref is the reference followed to reach the next visited node.
+	ref string
+}
+
+// OnEnter registers a hook called on the nodes of type *T before their children.
+func OnEnter[T any](w *Walker, fn func(node *WalkNode, value *T) error) {
+	if w.enter == nil {
+		w.enter = map[reflect.Type][]func(node *WalkNode) error{}
+	}
+
+	t := reflect.TypeOf((*T)(nil))
+	w.enter[t] = append(w.enter[t], func(node *WalkNode) error {
+		return fn(node, node.Value.(*T))
+	})
+}
+
+// OnLeave registers a hook called on the nodes of type *T after their children.
+func OnLeave[T any](w *Walker, fn func(node *WalkNode, value *T) error) {
+	if w.leave == nil {
+		w.leave = map[reflect.Type][]func(node *WalkNode) error{}
+	}
+
+	t := reflect.TypeOf((*T)(nil))
+	w.leave[t] = append(w.leave[t], func(node *WalkNode) error {
+		return fn(node, node.Value.(*T))
+	})
+}
+
+// Walk traverses the document in a deterministic order: the fields in the order of the specification,
+// the map entries sorted by key.
+func (w *Walker) Walk(doc *OpenAPI) error {
+	w.doc = doc
+	w.following = nil
+
+	err := w.visit(nil, "", doc, func(node *WalkNode) error {
+		if err := w.visit(node, "/info", &doc.Info, nil); err != nil {
+			return err
+		}
+		if err := walkSlice(node, "/servers", doc.Servers, w.server); err != nil {
+			return err
+		}
+		if err := walkMap(node, "/paths", doc.Paths, w.pathItem); err != nil {
+			return err
+		}
+		if err := walkMap(node, "/webhooks", doc.Webhooks, w.pathItem); err != nil {
+			return err
+		}
+		if doc.Components != nil {
+			if err := w.components(node, "/components", doc.Components); err != nil {
+				return err
+			}
+		}
+		if err := walkSlice(node, "/security", doc.Security, w.securityRequirement); err != nil {
+			return err
+		}
+		if err := walkSlice(node, "/tags", doc.Tags, w.tag); err != nil {
+			return err
+		}
+		return w.externalDocs(node, "/externalDocs", doc.ExternalDocs)
+	})
+	if err != nil {
+		return fmt.Errorf("walk: %w", err)
+	}
+
+	return nil
+}
+
+// visit calls the hooks of a node around the traversal of its children.
+func (w *Walker) visit(parent *WalkNode, ptr string, value any, children func(node *WalkNode) error) error {
+	node := &WalkNode{Pointer: ptr, Value: value, Parent: parent, Ref: w.ref}
+	w.ref = ""
+
+	if err := w.call(w.Enter, w.enter, node); err != nil {
+		if errors.Is(err, SkipNode) {
+			return nil
+		}
+		return err
+	}
+
+	if children != nil {
+		if err := children(node); err != nil {
+			return err
+		}
+	}
+
+	err := w.call(w.Leave, w.leave, node)
+	if errors.Is(err, SkipNode) {
+		return nil
+	}
+
+	return err
+}
+
+func (w *Walker) call(hook func(node *WalkNode) error, hooks map[reflect.Type][]func(node *WalkNode) error, node *WalkNode) error {
+	if hook != nil {
+		if err := hook(node); err != nil {
+			return err
+		}
+	}
+
+	for _, fn := range hooks[reflect.TypeOf(node.Value)] {
+		if err := fn(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Walker) components(parent *WalkNode, ptr string, c *Components) error {
+	return w.visit(parent, ptr, c, func(node *WalkNode) error {
+		if err := walkMap(node, ptr+"/schemas", c.Schemas, w.schema); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/responses", c.Responses, w.response); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/parameters", c.Parameters, w.parameter); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/examples", c.Examples, w.example); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/requestBodies", c.RequestBodies, w.requestBody); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/headers", c.Headers, w.header); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/securitySchemes", c.SecuritySchemes, w.securityScheme); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/links", c.Links, w.link); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/callbacks", c.Callbacks, w.callback); err != nil {
+			return err
+		}
+		return walkMap(node, ptr+"/pathItems", c.PathItems, w.pathItem)
+	})
+}
+
+func (w *Walker) pathItem(parent *WalkNode, ptr string, pi *PathItem) error {
+	return w.visit(parent, ptr, pi, func(node *WalkNode) error {
+		if err := followRef(w, node, pi.Ref, "pathItems", w.componentPathItems(), w.pathItem); err != nil {
+			return err
+		}
+
+		for _, mo := range pi.Operations() {
+			if err := w.operation(node, appendPointer(ptr, strings.ToLower(mo.Method)), mo.Operation); err != nil {
+				return err
+			}
+		}
+
+		if err := walkSlice(node, ptr+"/servers", pi.Servers, w.server); err != nil {
+			return err
+		}
+		return walkSlice(node, ptr+"/parameters", pi.Parameters, w.parameter)
+	})
+}
+
+func (w *Walker) operation(parent *WalkNode, ptr string, op *Operation) error {
+	return w.visit(parent, ptr, op, func(node *WalkNode) error {
+		if err := w.externalDocs(node, ptr+"/externalDocs", op.ExternalDocs); err != nil {
+			return err
+		}
+		if err := walkSlice(node, ptr+"/parameters", op.Parameters, w.parameter); err != nil {
+			return err
+		}
+		if op.RequestBody != nil {
+			if err := w.requestBody(node, ptr+"/requestBody", op.RequestBody); err != nil {
+				return err
+			}
+		}
+		if op.Responses != nil {
+			if err := walkMap(node, ptr+"/responses", *op.Responses, w.response); err != nil {
+				return err
+			}
+		}
+		if err := walkMap(node, ptr+"/callbacks", op.Callbacks, w.callback); err != nil {
+			return err
+		}
+		if err := walkSlice(node, ptr+"/security", op.Security, w.securityRequirement); err != nil {
+			return err
+		}
+		return walkSlice(node, ptr+"/servers", op.Servers, w.server)
+	})
+}
+
+func (w *Walker) parameter(parent *WalkNode, ptr string, p *Parameter) error {
+	return w.visit(parent, ptr, p, func(node *WalkNode) error {
+		if err := followRef(w, node, p.Ref, "parameters", w.componentParameters(), w.parameter); err != nil {
+			return err
+		}
+		return w.parameterChildren(node, ptr, p)
+	})
+}
+
+func (w *Walker) header(parent *WalkNode, ptr string, h *Header) error {
+	return w.visit(parent, ptr, h, func(node *WalkNode) error {
+		if err := followRef(w, node, h.Ref, "headers", w.componentHeaders(), w.header); err != nil {
+			return err
+		}
+		return w.parameterChildren(node, ptr, &h.Parameter)
+	})
+}
+
+// parameterChildren walks the children shared by the parameters and the headers.
+func (w *Walker) parameterChildren(node *WalkNode, ptr string, p *Parameter) error {
+	if p.Schema != nil {
+		if err := w.schema(node, ptr+"/schema", p.Schema); err != nil {
+			return err
+		}
+	}
+	if err := walkMap(node, ptr+"/examples", p.Examples, w.example); err != nil {
+		return err
+	}
+	return walkMap(node, ptr+"/content", p.Content, w.mediaType)
+}
+
+func (w *Walker) requestBody(parent *WalkNode, ptr string, rb *RequestBody) error {
+	return w.visit(parent, ptr, rb, func(node *WalkNode) error {
+		if err := followRef(w, node, rb.Ref, "requestBodies", w.componentRequestBodies(), w.requestBody); err != nil {
+			return err
+		}
+		return walkMap(node, ptr+"/content", rb.Content, w.mediaType)
+	})
+}
+
+func (w *Walker) response(parent *WalkNode, ptr string, r *Response) error {
+	return w.visit(parent, ptr, r, func(node *WalkNode) error {
+		if err := followRef(w, node, r.Ref, "responses", w.componentResponses(), w.response); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/headers", r.Headers, w.header); err != nil {
+			return err
+		}
+		if err := walkMap(node, ptr+"/content", r.Content, w.mediaType); err != nil {
+			return err
+		}
+		return walkMap(node, ptr+"/links", r.Links, w.link)
+	})
+}
+
+func (w *Walker) mediaType(parent *WalkNode, ptr string, m *MediaType) error {
+	return w.visit(parent, ptr, m, func(node *WalkNode) error {
+		if m.Schema != nil {
+			if err := w.schema(node, ptr+"/schema", m.Schema); err != nil {
+				return err
+			}
+		}
+		if err := walkMap(node, ptr+"/examples", m.Examples, w.example); err != nil {
+			return err
+		}
+		return walkMap(node, ptr+"/encoding", m.Encoding, w.encoding)
+	})
+}
+
+func (w *Walker) encoding(parent *WalkNode, ptr string, e *Encoding) error {
+	return w.visit(parent, ptr, e, func(node *WalkNode) error {
+		return walkMap(node, ptr+"/headers", e.Headers, w.header)
+	})
+}
+
+func (w *Walker) example(parent *WalkNode, ptr string, ex *Example) error {
+	return w.visit(parent, ptr, ex, func(node *WalkNode) error {
+		return followRef(w, node, ex.Ref, "examples", w.componentExamples(), w.example)
+	})
+}
+
+func (w *Walker) link(parent *WalkNode, ptr string, l *Link) error {
+	return w.visit(parent, ptr, l, func(node *WalkNode) error {
+		if err := followRef(w, node, l.Ref, "links", w.componentLinks(), w.link); err != nil {
+			return err
+		}
+		if l.Server != nil {
+			return w.server(node, ptr+"/server", l.Server)
+		}
+		return nil
+	})
+}
+
+func (w *Walker) callback(parent *WalkNode, ptr string, c *Callback) error {
+	return w.visit(parent, ptr, c, func(node *WalkNode) error {
+		return walkMap(node, ptr, *c, w.pathItem)
+	})
+}
+
+func (w *Walker) schema(parent *WalkNode, ptr string, s *Schema) error {
+	return w.visit(parent, ptr, s, func(node *WalkNode) error {
+		if err := w.jsonSchemaChildren(node, ptr, &s.JSONSchema); err != nil {
+			return err
+		}
+		return w.externalDocs(node, ptr+"/externalDocs", s.ExternalDocs)
+	})
+}
+
+func (w *Walker) jsonSchema(parent *WalkNode, ptr string, s *JSONSchema) error {
+	return w.visit(parent, ptr, s, func(node *WalkNode) error {
+		return w.jsonSchemaChildren(node, ptr, s)
+	})
+}
+
+// jsonSchemaChildren walks the referenced schema, the schema of the items, the subschemas,
+// the schema of the additional properties and the properties of a schema.
+func (w *Walker) jsonSchemaChildren(node *WalkNode, ptr string, s *JSONSchema) error {
+	if err := followRef(w, node, s.Ref, "schemas", w.componentSchemas(), w.schema); err != nil {
+		return err
+	}
+	if s.Items != nil {
+		if err := w.jsonSchema(node, ptr+"/items", s.Items); err != nil {
+			return err
+		}
+	}
+	if err := walkSlice(node, ptr+"/allOf", s.AllOf, w.jsonSchema); err != nil {
+		return err
+	}
+	if err := walkSlice(node, ptr+"/anyOf", s.AnyOf, w.jsonSchema); err != nil {
+		return err
+	}
+	if err := walkSlice(node, ptr+"/oneOf", s.OneOf, w.jsonSchema); err != nil {
+		return err
+	}
+	if s.Not != nil {
+		if err := w.jsonSchema(node, ptr+"/not", s.Not); err != nil {
+			return err
+		}
+	}
+	if additional, _ := s.AdditionalPropertiesSchema(); additional != nil {
+		if err := w.jsonSchema(node, ptr+"/additionalProperties", additional); err != nil {
+			return err
+		}
+		// The schema is set back only if a hook changed it, to keep the original form of the additional properties.
+		if original, _ := s.AdditionalPropertiesSchema(); !reflect.DeepEqual(original, additional) {
+			s.AdditionalProperties = *additional
+		}
+	}
+	return walkMap(node, ptr+"/properties", s.Properties, w.jsonSchema)
+}
+
+func (w *Walker) server(parent *WalkNode, ptr string, s *Server) error {
+	return w.visit(parent, ptr, s, nil)
+}
+
+func (w *Walker) securityRequirement(parent *WalkNode, ptr string, s *SecurityRequirement) error {
+	return w.visit(parent, ptr, s, nil)
+}
+
+func (w *Walker) securityScheme(parent *WalkNode, ptr string, s *SecurityScheme) error {
+	return w.visit(parent, ptr, s, nil)
+}
+
+func (w *Walker) tag(parent *WalkNode, ptr string, t *Tag) error {
+	return w.visit(parent, ptr, t, func(node *WalkNode) error {
+		return w.externalDocs(node, ptr+"/externalDocs", t.ExternalDocs)
+	})
+}
+
+func (w *Walker) externalDocs(parent *WalkNode, ptr string, ed *ExternalDocumentation) error {
+	if ed == nil {
+		return nil
+	}
+
+	return w.visit(parent, ptr, ed, nil)
+}
+
+func (w *Walker) componentSchemas() map[string]Schema { return w.doc.components().Schemas }
+
+func (w *Walker) componentResponses() map[string]Response { return w.doc.components().Responses }
+
+func (w *Walker) componentParameters() map[string]Parameter { return w.doc.components().Parameters }
+
+func (w *Walker) componentExamples() map[string]Example { return w.doc.components().Examples }
+
+func (w *Walker) componentRequestBodies() map[string]RequestBody {
+	return w.doc.components().RequestBodies
+}
+
+func (w *Walker) componentHeaders() map[string]Header { return w.doc.components().Headers }
+
+func (w *Walker) componentLinks() map[string]Link { return w.doc.components().Links }
+
+func (w *Walker) componentPathItems() map[string]PathItem { return w.doc.components().PathItems }
+
+// followRef walks the target of a local reference to a component, if the walker follows the references.
+// The target is visited with its own pointer, as a child of the node holding the reference.
+func followRef[V any](w *Walker, parent *WalkNode, ref, field string, components map[string]V, fn func(parent *WalkNode, ptr string, v *V) error) error {
+	prefix := "#/components/" + field + "/"
+	if !w.FollowRefs || !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+
+	for _, following := range w.following {
+		if following == ref {
+			return nil
+		}
+	}
+
+	name := strings.TrimPrefix(ref, prefix)
+	target, ok := components[name]
+	if !ok {
+		return nil
+	}
+
+	w.following = append(w.following, ref)
+	defer func() { w.following = w.following[:len(w.following)-1] }()
+
+	w.ref = ref
+	err := fn(parent, appendPointer("/components", field, name), &target)
+	writeBack(components, name, target)
+
+	return err
+}
+
+// walkMap walks the values of a map in key order, writing back the changes made by the hooks.
+func walkMap[V any](parent *WalkNode, ptr string, m map[string]V, fn func(parent *WalkNode, ptr string, v *V) error) error {
+	for _, key := range sortedKeys(m) {
+		v := m[key]
+		if err := fn(parent, appendPointer(ptr, key), &v); err != nil {
+			return err
+		}
+		writeBack(m, key, v)
+	}
+
+	return nil
+}
+
+// writeBack sets the walked copy of a map value if a hook changed it, so that a walk without changes
+// leaves the document untouched and does not restore an entry removed by a hook.
+func writeBack[V any](m map[string]V, key string, v V) {
+	if original, ok := m[key]; ok && !reflect.DeepEqual(original, v) {
+		m[key] = v
+	}
+}
+
+// walkSlice walks the elements of a slice in order.
+func walkSlice[V any](parent *WalkNode, ptr string, s []V, fn func(parent *WalkNode, ptr string, v *V) error) error {
+	for i := range s {
+		if err := fn(parent, appendPointer(ptr, fmt.Sprint(i)), &s[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
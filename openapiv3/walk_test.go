@@ -0,0 +1,194 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_Walk(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	var operations, responses []string
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, op *Operation) error {
+		operations = append(operations, node.Pointer)
+		return nil
+	})
+	OnEnter(w, func(node *WalkNode, r *Response) error {
+		responses = append(responses, node.Pointer)
+		return SkipNode
+	})
+
+	err = w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/paths/~1pets/get", "/paths/~1pets/post", "/paths/~1pets~1{petId}/get"}, operations)
+	assert.Equal(t, []string{
+		"/paths/~1pets/get/responses/200",
+		"/paths/~1pets/get/responses/default",
+		"/paths/~1pets/post/responses/201",
+		"/paths/~1pets/post/responses/default",
+		"/paths/~1pets~1{petId}/get/responses/200",
+		"/paths/~1pets~1{petId}/get/responses/default",
+	}, responses)
+}
+
+func TestWalker_Walk_skip(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	var pointers []string
+	w := &Walker{
+		Enter: func(node *WalkNode) error {
+			pointers = append(pointers, node.Pointer)
+			if _, ok := node.Value.(*PathItem); ok {
+				return SkipNode
+			}
+			if _, ok := node.Value.(*Components); ok {
+				return SkipNode
+			}
+			return nil
+		},
+	}
+
+	err = w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "/info", "/servers/0", "/paths/~1pets", "/paths/~1pets~1{petId}", "/components"}, pointers)
+}
+
+func TestWalker_Walk_followRefs(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	// Make the Pet schema recursive.
+	oas.Components.Schemas["Pet"].Properties["parent"] = JSONSchema{Ref: "#/components/schemas/Pet"}
+
+	var refs []string
+	w := &Walker{FollowRefs: true}
+	OnEnter(w, func(node *WalkNode, s *Schema) error {
+		if node.Ref != "" {
+			refs = append(refs, node.Parent.Pointer+" -> "+node.Pointer)
+		}
+		return nil
+	})
+
+	err = w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/paths/~1pets/get/responses/200/content/application~1json/schema -> /components/schemas/Pets",
+		"/components/schemas/Pets/items -> /components/schemas/Pet",
+		"/paths/~1pets/get/responses/default/content/application~1json/schema -> /components/schemas/Error",
+		"/paths/~1pets/post/responses/default/content/application~1json/schema -> /components/schemas/Error",
+		"/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema -> /components/schemas/Pet",
+		"/paths/~1pets~1{petId}/get/responses/default/content/application~1json/schema -> /components/schemas/Error",
+		// The recursive reference is only followed from the component itself.
+		"/components/schemas/Pet/properties/parent -> /components/schemas/Pet",
+		"/components/schemas/Pets/items -> /components/schemas/Pet",
+	}, refs)
+}
+
+func TestWalker_Walk_update(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, p *Parameter) error {
+		p.Description = "updated"
+		return nil
+	})
+	OnEnter(w, func(node *WalkNode, s *JSONSchema) error {
+		s.Format = "updated"
+		return nil
+	})
+
+	err = w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, "updated", oas.Paths["/pets"].Get.Parameters[0].Description)
+	assert.Equal(t, "updated", oas.Components.Schemas["Pet"].Properties["name"].Format)
+}
+
+func TestWalker_Walk_remove(t *testing.T) {
+	oas, err := FromFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, s *Schema) error {
+		if node.Pointer == "/components/schemas/Error" {
+			delete(oas.Components.Schemas, "Error")
+		}
+		return nil
+	})
+
+	err = w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Pet", "Pets"}, sortedKeys(oas.Components.Schemas))
+}
+
+func TestWalker_Walk_readOnly(t *testing.T) {
+	var oas OpenAPI
+	err := json.Unmarshal([]byte(`{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Labels": {"type": "object", "additionalProperties": {"type": "string", "const": "cat"}}
+    }
+  }
+}`), &oas)
+	require.NoError(t, err)
+
+	var pointers []string
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, s *JSONSchema) error {
+		pointers = append(pointers, node.Pointer)
+		return nil
+	})
+
+	err = w.Walk(&oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/components/schemas/Labels/additionalProperties"}, pointers)
+	// The additional properties keep their decoded form, with the keywords unknown to the model.
+	assert.Equal(t, map[string]any{"type": "string", "const": "cat"}, oas.Components.Schemas["Labels"].AdditionalProperties)
+}
+
+func TestWalker_Walk_subschemas(t *testing.T) {
+	oas := &OpenAPI{
+		Components: &Components{
+			Schemas: map[string]Schema{
+				"Pet": {JSONSchema: JSONSchema{
+					AllOf: []JSONSchema{{Properties: map[string]JSONSchema{"name": {Type: "string"}}}},
+					AnyOf: []JSONSchema{{Type: "object"}},
+					OneOf: []JSONSchema{{Required: []string{"name"}}},
+					Not:   &JSONSchema{Type: "null"},
+				}},
+			},
+		},
+	}
+
+	var pointers []string
+	w := &Walker{}
+	OnEnter(w, func(node *WalkNode, s *JSONSchema) error {
+		pointers = append(pointers, node.Pointer)
+		return nil
+	})
+
+	err := w.Walk(oas)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/components/schemas/Pet/allOf/0",
+		"/components/schemas/Pet/allOf/0/properties/name",
+		"/components/schemas/Pet/anyOf/0",
+		"/components/schemas/Pet/oneOf/0",
+		"/components/schemas/Pet/not",
+	}, pointers)
+}
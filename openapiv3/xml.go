@@ -23,10 +23,10 @@ type XML struct {
 	Prefix string `json:"prefix,omitempty"`
 	// Declares whether the property definition translates to an attribute instead of an element.
 	// Default value is false.
-	Attribute bool `json:"attribute"`
+	Attribute bool `json:"attribute,omitempty"`
 	// MAY be used only for an array definition.
 	// Signifies whether the array is wrapped (for example, <books><book/><book/></books>) or unwrapped (<book/><book/>).
 	// The definition takes effect only when defined alongside type being array (outside the items).
 	// Default value is false.
-	Wrapped bool `json:"wrapped"`
+	Wrapped bool `json:"wrapped,omitempty"`
 }